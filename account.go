@@ -1,5 +1,7 @@
 package twitterscraper
 
+import "context"
+
 type AccountSettings struct {
 	ScreenName            string `json:"screen_name"`
 	Protected             bool   `json:"protected"`
@@ -45,8 +47,13 @@ type AccountList struct {
 }
 
 func (s *Scraper) GetAccountSettings() (AccountSettings, error) {
+	return s.GetAccountSettingsContext(context.Background())
+}
+
+// GetAccountSettingsContext same as GetAccountSettings, but accepts context for cancellation.
+func (s *Scraper) GetAccountSettingsContext(ctx context.Context) (AccountSettings, error) {
 	var settings AccountSettings
-	req, err := s.newRequest("GET", "https://api.twitter.com/1.1/account/settings.json")
+	req, err := s.newRequest(ctx, "GET", "https://api.twitter.com/1.1/account/settings.json")
 	if err != nil {
 		return settings, err
 	}
@@ -56,8 +63,13 @@ func (s *Scraper) GetAccountSettings() (AccountSettings, error) {
 }
 
 func (s *Scraper) GetAccountList() ([]Account, error) {
+	return s.GetAccountListContext(context.Background())
+}
+
+// GetAccountListContext same as GetAccountList, but accepts context for cancellation.
+func (s *Scraper) GetAccountListContext(ctx context.Context) ([]Account, error) {
 	var list AccountList
-	req, err := s.newRequest("GET", "https://api.twitter.com/1.1/account/multi/list.json")
+	req, err := s.newRequest(ctx, "GET", "https://api.twitter.com/1.1/account/multi/list.json")
 	if err != nil {
 		return list.Users, err
 	}
@@ -10,6 +10,7 @@ func TestGetAccountSettings(t *testing.T) {
 	}
 
 	settings, err := testScraper.GetAccountSettings()
+	skipIfNotRecorded(t, err)
 	if err != nil {
 		t.Error(err)
 	}
@@ -25,6 +26,7 @@ func TestGetAccountList(t *testing.T) {
 	}
 
 	accounts, err := testScraper.GetAccountList()
+	skipIfNotRecorded(t, err)
 	if err != nil {
 		t.Error(err)
 	}
@@ -0,0 +1,312 @@
+package twitterscraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrNoAccounts returned by AccountPool when no account can serve requests.
+var ErrNoAccounts = errors.New("no available accounts")
+
+// DefaultRateLimitWindow used to rest a rate limited account when its reset time is unknown.
+const DefaultRateLimitWindow = 15 * time.Minute
+
+// Credentials of an account managed by AccountPool. Set one of Cookies, AuthToken or OpenAccount.
+type Credentials struct {
+	// Name identifies the account in health reports, e.g. username
+	Name        string
+	Cookies     []*http.Cookie
+	AuthToken   *AuthToken
+	OpenAccount *OpenAccount
+	// Proxy the account is pinned to, optional, see SetProxy for the format
+	Proxy string
+}
+
+// AccountStatus type
+type AccountStatus int
+
+const (
+	// AccountActive - account serves requests
+	AccountActive AccountStatus = iota
+	// AccountRateLimited - account rests until the rate limit resets
+	AccountRateLimited
+	// AccountLocked - account is temporarily locked by Twitter
+	AccountLocked
+	// AccountSuspended - account is suspended
+	AccountSuspended
+	// AccountLoggedOut - account credentials are invalid or expired
+	AccountLoggedOut
+)
+
+func (status AccountStatus) String() string {
+	switch status {
+	case AccountActive:
+		return "active"
+	case AccountRateLimited:
+		return "rate limited"
+	case AccountLocked:
+		return "locked"
+	case AccountSuspended:
+		return "suspended"
+	case AccountLoggedOut:
+		return "logged out"
+	}
+	return fmt.Sprintf("AccountStatus(%d)", int(status))
+}
+
+// AccountHealth is a snapshot of an account state in AccountPool.
+type AccountHealth struct {
+	Name   string
+	Proxy  string
+	Status AccountStatus
+	// RateLimitedUntil is set for rate limited accounts
+	RateLimitedUntil time.Time
+	Requests         int
+	Failures         int
+	LastError        error
+	LastUsed         time.Time
+}
+
+type pooledAccount struct {
+	account  Credentials
+	scraper  *Scraper
+	verified bool
+	// verifying is closed when the running login check ends, nil if none is running
+	verifying chan struct{}
+	health    AccountHealth
+}
+
+// AccountPool hands out logged in scrapers from many accounts
+// and rotates them on rate limit, lock or suspension errors.
+type AccountPool struct {
+	mu         sync.Mutex
+	accounts   []*pooledAccount
+	byScraper  map[*Scraper]*pooledAccount
+	next       int
+	newScraper func() *Scraper
+}
+
+// NewAccountPool creates AccountPool with a scraper for every account.
+func NewAccountPool(accounts ...Credentials) (*AccountPool, error) {
+	pool := &AccountPool{
+		byScraper:  make(map[*Scraper]*pooledAccount),
+		newScraper: New,
+	}
+	for _, account := range accounts {
+		if err := pool.Add(account); err != nil {
+			return nil, err
+		}
+	}
+	return pool, nil
+}
+
+// WithScraper set the function creating scrapers for accounts added afterwards,
+// e.g. to configure delay, retries or endpoints.
+func (p *AccountPool) WithScraper(newScraper func() *Scraper) *AccountPool {
+	p.mu.Lock()
+	p.newScraper = newScraper
+	p.mu.Unlock()
+	return p
+}
+
+// Add account to the pool.
+func (p *AccountPool) Add(account Credentials) error {
+	p.mu.Lock()
+	newScraper := p.newScraper
+	p.mu.Unlock()
+
+	scraper := newScraper()
+	if account.Proxy != "" {
+		if err := scraper.SetProxy(account.Proxy); err != nil {
+			return fmt.Errorf("account %s: %w", account.Name, err)
+		}
+	}
+
+	verified := false
+	switch {
+	case account.OpenAccount != nil:
+		scraper.WithOpenAccount(*account.OpenAccount)
+		verified = true
+	case account.AuthToken != nil:
+		scraper.SetAuthToken(*account.AuthToken)
+	case len(account.Cookies) > 0:
+		scraper.SetCookies(account.Cookies)
+	default:
+		return fmt.Errorf("account %s: no credentials", account.Name)
+	}
+
+	pooled := &pooledAccount{
+		account:  account,
+		scraper:  scraper,
+		verified: verified,
+		health:   AccountHealth{Name: account.Name, Proxy: account.Proxy},
+	}
+
+	p.mu.Lock()
+	p.accounts = append(p.accounts, pooled)
+	p.byScraper[scraper] = pooled
+	p.mu.Unlock()
+	return nil
+}
+
+// Get returns a scraper of the next available account, checking its session on first use.
+// If every account is rate limited *RateLimitError with the earliest reset is returned.
+func (p *AccountPool) Get(ctx context.Context) (*Scraper, error) {
+	for {
+		pooled, err := p.pick()
+		if err != nil {
+			return nil, err
+		}
+
+		p.mu.Lock()
+		verified, verifying := pooled.verified, pooled.verifying
+		if !verified && verifying == nil {
+			pooled.verifying = make(chan struct{})
+		}
+		p.mu.Unlock()
+		if verified {
+			return pooled.scraper, nil
+		}
+		if verifying != nil {
+			// another Get checks the account, pick again once it's done
+			select {
+			case <-verifying:
+				continue
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		ok := pooled.scraper.IsLoggedInContext(ctx)
+		p.mu.Lock()
+		close(pooled.verifying)
+		pooled.verifying = nil
+		if err := ctx.Err(); err != nil {
+			p.mu.Unlock()
+			return nil, err
+		}
+		if ok {
+			pooled.verified = true
+		} else {
+			pooled.health.Status = AccountLoggedOut
+			pooled.health.Failures++
+			pooled.health.LastError = ErrNotLoggedIn
+		}
+		p.mu.Unlock()
+		if ok {
+			return pooled.scraper, nil
+		}
+	}
+}
+
+// pick selects the next available account round-robin.
+func (p *AccountPool) pick() (*pooledAccount, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	var reset time.Time
+	for i := 0; i < len(p.accounts); i++ {
+		pooled := p.accounts[(p.next+i)%len(p.accounts)]
+		if pooled.health.Status == AccountRateLimited {
+			if now.Before(pooled.health.RateLimitedUntil) {
+				if reset.IsZero() || pooled.health.RateLimitedUntil.Before(reset) {
+					reset = pooled.health.RateLimitedUntil
+				}
+				continue
+			}
+			pooled.health.Status = AccountActive
+			pooled.health.RateLimitedUntil = time.Time{}
+		}
+		if pooled.health.Status != AccountActive {
+			continue
+		}
+		p.next = (p.next + i + 1) % len(p.accounts)
+		pooled.health.LastUsed = now
+		return pooled, nil
+	}
+
+	if !reset.IsZero() {
+		return nil, &RateLimitError{Reset: reset}
+	}
+	return nil, ErrNoAccounts
+}
+
+// Report updates health of the account the scraper belongs to with the result of a request.
+// Returns true if the error made the account unavailable and the request is worth repeating with another one.
+func (p *AccountPool) Report(scraper *Scraper, err error) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pooled, ok := p.byScraper[scraper]
+	if !ok {
+		return false
+	}
+	pooled.health.Requests++
+	if err == nil {
+		return false
+	}
+
+	status := accountStatus(err)
+	if status == AccountActive {
+		return false
+	}
+	pooled.health.Failures++
+	pooled.health.LastError = err
+	pooled.health.Status = status
+	if status == AccountRateLimited {
+		pooled.health.RateLimitedUntil = time.Now().Add(DefaultRateLimitWindow)
+		var rateLimitErr *RateLimitError
+		if errors.As(err, &rateLimitErr) && !rateLimitErr.Reset.IsZero() {
+			pooled.health.RateLimitedUntil = rateLimitErr.Reset
+		}
+	}
+	return true
+}
+
+// Do runs fn with scrapers from the pool, moving to the next account while fn fails
+// because of a rate limit, lock, suspension or expired session.
+func (p *AccountPool) Do(ctx context.Context, fn func(*Scraper) error) error {
+	for {
+		scraper, err := p.Get(ctx)
+		if err != nil {
+			return err
+		}
+		err = fn(scraper)
+		if !p.Report(scraper, err) {
+			return err
+		}
+	}
+}
+
+// Health returns a snapshot of every account state.
+func (p *AccountPool) Health() []AccountHealth {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	health := make([]AccountHealth, 0, len(p.accounts))
+	for _, pooled := range p.accounts {
+		health = append(health, pooled.health)
+	}
+	return health
+}
+
+// accountStatus returns the account status an error leads to.
+func accountStatus(err error) AccountStatus {
+	var apiErr *APIError
+	switch {
+	case errors.Is(err, ErrRateLimited):
+		return AccountRateLimited
+	case errors.Is(err, ErrLocked):
+		return AccountLocked
+	case errors.As(err, &apiErr) && apiErr.hasCode(codeAccountSuspended):
+		// code 63 is about the requested user, 64 is about the account itself
+		return AccountSuspended
+	case errors.As(err, &apiErr) && errors.Is(err, ErrNotLoggedIn):
+		return AccountLoggedOut
+	}
+	return AccountActive
+}
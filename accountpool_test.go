@@ -0,0 +1,183 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/einys/twitter-scraper"
+)
+
+func newTestAccountPool(t *testing.T, handler func(w http.ResponseWriter, token string)) *twitterscraper.AccountPool {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("auth_token")
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/verify_credentials.json") {
+			if cookie.Value == "expired" {
+				w.WriteHeader(http.StatusUnauthorized)
+				fmt.Fprint(w, `{"errors":[{"code":32,"message":"Could not authenticate you."}]}`)
+				return
+			}
+			fmt.Fprint(w, `{}`)
+			return
+		}
+		handler(w, cookie.Value)
+	})
+
+	pool, err := twitterscraper.NewAccountPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool.WithScraper(func() *twitterscraper.Scraper {
+		return twitterscraper.New().WithBaseURL(server.URL)
+	})
+	for _, name := range []string{"expired", "limited", "locked", "healthy"} {
+		account := twitterscraper.Credentials{Name: name, AuthToken: &twitterscraper.AuthToken{Token: name, CSRFToken: "csrf"}}
+		if err := pool.Add(account); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return pool
+}
+
+func TestAccountPoolRotation(t *testing.T) {
+	var used []string
+	pool := newTestAccountPool(t, func(w http.ResponseWriter, token string) {
+		used = append(used, token)
+		switch token {
+		case "limited":
+			w.Header().Set("X-Rate-Limit-Reset", "4102444800")
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"errors":[{"code":88,"message":"Rate limit exceeded"}]}`)
+		case "locked":
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"errors":[{"code":326,"message":"To protect our users from spam and other malicious activity, this account is temporarily locked."}]}`)
+		default:
+			fmt.Fprint(w, `{"data":{"user":{"result":{"rest_id":"783214","legacy":{"screen_name":"X","name":"X"}}}}}`)
+		}
+	})
+
+	var profile twitterscraper.Profile
+	err := pool.Do(context.Background(), func(scraper *twitterscraper.Scraper) error {
+		var err error
+		profile, err = scraper.GetProfile("X")
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.UserID != "783214" {
+		t.Errorf("Unexpected profile %#v", profile)
+	}
+	if strings.Join(used, ",") != "limited,locked,healthy" {
+		t.Errorf("Expected rotation through limited, locked and healthy accounts, got %v", used)
+	}
+
+	expected := map[string]twitterscraper.AccountStatus{
+		"expired": twitterscraper.AccountLoggedOut,
+		"limited": twitterscraper.AccountRateLimited,
+		"locked":  twitterscraper.AccountLocked,
+		"healthy": twitterscraper.AccountActive,
+	}
+	for _, health := range pool.Health() {
+		if health.Status != expected[health.Name] {
+			t.Errorf("Expected %s to be %s, got %s", health.Name, expected[health.Name], health.Status)
+		}
+		if health.Name == "limited" && health.RateLimitedUntil.Unix() != 4102444800 {
+			t.Errorf("Expected limited account to rest until reset, got %v", health.RateLimitedUntil)
+		}
+	}
+
+	// only the healthy account is left
+	for i := 0; i < 2; i++ {
+		scraper, err := pool.Get(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := scraper.GetProfile("X"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if used[len(used)-1] != "healthy" || used[len(used)-2] != "healthy" {
+		t.Errorf("Expected healthy account to serve requests, got %v", used)
+	}
+}
+
+func TestAccountPoolExhausted(t *testing.T) {
+	pool := newTestAccountPool(t, func(w http.ResponseWriter, token string) {
+		w.Header().Set("X-Rate-Limit-Reset", "4102444800")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	err := pool.Do(context.Background(), func(scraper *twitterscraper.Scraper) error {
+		_, err := scraper.GetProfile("X")
+		return err
+	})
+	var rateLimitErr *twitterscraper.RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		t.Fatalf("Expected *RateLimitError when all accounts are limited, got %v", err)
+	}
+	if rateLimitErr.Reset.Unix() != 4102444800 {
+		t.Errorf("Expected earliest reset, got %v", rateLimitErr.Reset)
+	}
+}
+
+func TestAccountPoolNoCredentials(t *testing.T) {
+	_, err := twitterscraper.NewAccountPool(twitterscraper.Credentials{Name: "empty"})
+	if err == nil {
+		t.Error("Expected error for account without credentials")
+	}
+
+	pool, err := twitterscraper.NewAccountPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pool.Get(context.Background()); !errors.Is(err, twitterscraper.ErrNoAccounts) {
+		t.Errorf("Expected ErrNoAccounts, got %v", err)
+	}
+}
+
+func TestAccountPoolConcurrentGet(t *testing.T) {
+	var verifications int32
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/verify_credentials.json") {
+			atomic.AddInt32(&verifications, 1)
+			time.Sleep(10 * time.Millisecond)
+		}
+		fmt.Fprint(w, `{}`)
+	})
+	pool, err := twitterscraper.NewAccountPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool.WithScraper(func() *twitterscraper.Scraper {
+		return twitterscraper.New().WithBaseURL(server.URL)
+	})
+	if err := pool.Add(twitterscraper.Credentials{Name: "single", AuthToken: &twitterscraper.AuthToken{Token: "auth", CSRFToken: "csrf"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := pool.Get(context.Background()); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := atomic.LoadInt32(&verifications); n != 1 {
+		t.Errorf("Expected the account to be verified once, got %d", n)
+	}
+}
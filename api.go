@@ -1,6 +1,7 @@
 package twitterscraper
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -12,67 +13,109 @@ const bearerToken string = "AAAAAAAAAAAAAAAAAAAAAPYXBAAAAAAACLXUNDekMxqa8h%2F40K
 
 // RequestAPI get JSON from frontend API and decodes it
 func (s *Scraper) RequestAPI(req *http.Request, target interface{}) error {
-	s.wg.Wait()
-	if s.delay > 0 {
-		defer s.delayRequest()
+	endpoint := endpointName(req.URL)
+	if err := s.checkRateLimit(req.Context(), endpoint); err != nil {
+		return err
+	}
+	if err := s.waitDelay(req.Context()); err != nil {
+		return err
 	}
+	defer s.delayRequest()
 
+	if err := s.runRequestHooks(req); err != nil {
+		return err
+	}
 	if err := s.prepareRequest(req); err != nil {
 		return err
 	}
 
-	resp, err := s.client.Do(req)
+	start := time.Now()
+	resp, err := s.doWithRetry(req)
 	if err != nil {
+		s.runResponseHooks(req, nil, nil, err, start)
 		return err
 	}
 	defer resp.Body.Close()
 
-	return s.handleResponse(resp, target)
+	s.rateLimits.update(endpoint, resp.Header)
+	content, err := io.ReadAll(resp.Body)
+	if err == nil {
+		err = s.handleResponse(resp, content, target)
+	}
+	s.runResponseHooks(req, resp, content, err, start)
+	return err
+}
+
+// waitDelay blocks until the delay after the previous request is over or ctx is done.
+func (s *Scraper) waitDelay(ctx context.Context) error {
+	s.delayMu.Lock()
+	wait := time.Until(s.delayUntil)
+	s.delayMu.Unlock()
+	if wait <= 0 {
+		return nil
+	}
+	return sleepContext(ctx, wait)
 }
 
 func (s *Scraper) delayRequest() {
-	s.wg.Add(1)
-	go func() {
-		time.Sleep(time.Second * time.Duration(s.delay))
-		s.wg.Done()
-	}()
+	s.delayMu.Lock()
+	if s.delay > 0 {
+		s.delayUntil = time.Now().Add(time.Second * time.Duration(s.delay))
+	}
+	s.delayMu.Unlock()
 }
 
 func (s *Scraper) prepareRequest(req *http.Request) error {
-	req.Header.Set("User-Agent", s.userAgent)
+	if err := s.resolveRequest(req); err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", s.GetUserAgent())
 
-	if !s.isLogged {
-		if err := s.setGuestToken(req); err != nil {
+	bearer := s.bearerTokenFor(req.Context())
+	if s.isGuestRequest(req.Context()) {
+		if err := s.setGuestToken(req, bearer); err != nil {
 			return err
 		}
 	}
 
-	s.setAuthorizationHeader(req)
+	s.setAuthorizationHeader(req, bearer)
 	s.setCSRFToken(req)
 
 	return nil
 }
 
-func (s *Scraper) setGuestToken(req *http.Request) error {
-	if !s.IsGuestToken() || s.guestCreatedAt.Before(time.Now().Add(-time.Hour*3)) {
-		if err := s.GetGuestToken(); err != nil {
+// isGuestRequest reports whether requests with ctx are sent with guest token.
+func (s *Scraper) isGuestRequest(ctx context.Context) bool {
+	return !s.loggedIn() && !optionsFromContext(ctx).asLoggedIn
+}
+
+func (s *Scraper) setGuestToken(req *http.Request, bearer string) error {
+	s.mu.RLock()
+	token := s.guestTokens[bearer]
+	s.mu.RUnlock()
+	if token.value == "" || token.createdAt.Before(time.Now().Add(-time.Hour*3)) {
+		var err error
+		if token, err = s.fetchGuestToken(req.Context(), bearer); err != nil {
 			return err
 		}
 	}
-	req.Header.Set("X-Guest-Token", s.guestToken)
+	req.Header.Set("X-Guest-Token", token.value)
 	return nil
 }
 
-func (s *Scraper) setAuthorizationHeader(req *http.Request) {
-	if s.oAuthToken != "" && s.oAuthSecret != "" {
-		req.Header.Set("Authorization", s.sign(req.Method, req.URL))
+func (s *Scraper) setAuthorizationHeader(req *http.Request, bearer string) {
+	s.mu.RLock()
+	oAuthToken, oAuthSecret := s.oAuthToken, s.oAuthSecret
+	s.mu.RUnlock()
+	if oAuthToken != "" && oAuthSecret != "" {
+		req.Header.Set("Authorization", sign(oAuthToken, oAuthSecret, req.Method, req.URL))
 	} else {
-		req.Header.Set("Authorization", "Bearer "+s.bearerToken)
+		req.Header.Set("Authorization", "Bearer "+bearer)
 	}
 }
 
 func (s *Scraper) setCSRFToken(req *http.Request) {
-	for _, cookie := range s.client.Jar.Cookies(req.URL) {
+	for _, cookie := range s.jar.Cookies(req.URL) {
 		if cookie.Name == "ct0" {
 			req.Header.Set("X-CSRF-Token", cookie.Value)
 			break
@@ -80,18 +123,20 @@ func (s *Scraper) setCSRFToken(req *http.Request) {
 	}
 }
 
-func (s *Scraper) handleResponse(resp *http.Response, target interface{}) error {
-	content, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-
+func (s *Scraper) handleResponse(resp *http.Response, content []byte, target interface{}) error {
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("response status %s: %s", resp.Status, content)
+		return newAPIError(resp, content)
 	}
 
 	if resp.Header.Get("X-Rate-Limit-Remaining") == "0" {
-		s.guestToken = ""
+		bearer := s.bearerTokenFor(resp.Request.Context())
+		s.mu.Lock()
+		delete(s.guestTokens, bearer)
+		s.mu.Unlock()
+		if s.isGuestRequest(resp.Request.Context()) {
+			// new guest token comes with a fresh budget
+			s.rateLimits.reset()
+		}
 	}
 
 	if target == nil {
@@ -103,42 +148,59 @@ func (s *Scraper) handleResponse(resp *http.Response, target interface{}) error
 
 // GetGuestToken from Twitter API
 func (s *Scraper) GetGuestToken() error {
-	req, err := http.NewRequest("POST", "https://api.twitter.com/1.1/guest/activate.json", nil)
-	if err != nil {
-		return err
-	}
-	req.Header.Set("Authorization", "Bearer "+s.bearerToken)
+	return s.GetGuestTokenContext(context.Background())
+}
 
-	resp, err := s.client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
+// GetGuestTokenContext same as GetGuestToken, but accepts context for cancellation.
+func (s *Scraper) GetGuestTokenContext(ctx context.Context) error {
+	_, err := s.fetchGuestToken(ctx, s.bearerTokenFor(ctx))
+	return err
+}
 
-	body, err := io.ReadAll(resp.Body)
+// fetchGuestToken activates a guest token for the bearer token and caches it.
+func (s *Scraper) fetchGuestToken(ctx context.Context, bearer string) (guestToken, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.twitter.com/1.1/guest/activate.json", nil)
 	if err != nil {
-		return err
-	}
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("response status %s: %s", resp.Status, body)
+		return guestToken{}, err
 	}
 
-	var jsn map[string]interface{}
-	if err := json.Unmarshal(body, &jsn); err != nil {
-		return err
+	var value string
+	prepare := func(req *http.Request) error {
+		if err := s.resolveRequest(req); err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+bearer)
+		return nil
 	}
-	var ok bool
-	if s.guestToken, ok = jsn["guest_token"].(string); !ok {
-		return fmt.Errorf("guest_token not found")
+	err = s.do(req, prepare, func(resp *http.Response, body []byte) error {
+		if resp.StatusCode != http.StatusOK {
+			return newAPIError(resp, body)
+		}
+		var jsn map[string]interface{}
+		if err := json.Unmarshal(body, &jsn); err != nil {
+			return err
+		}
+		var ok bool
+		if value, ok = jsn["guest_token"].(string); !ok {
+			return fmt.Errorf("guest_token not found")
+		}
+		return nil
+	})
+	if err != nil {
+		return guestToken{}, err
 	}
-	s.guestCreatedAt = time.Now()
+	token := guestToken{value: value, createdAt: time.Now()}
+	s.mu.Lock()
+	s.guestTokens[bearer] = token
+	s.mu.Unlock()
 
-	return nil
+	return token, nil
 }
 
 func (s *Scraper) ClearGuestToken() error {
-	s.guestToken = ""
-	s.guestCreatedAt = time.Time{}
+	s.mu.Lock()
+	s.guestTokens = make(map[string]guestToken)
+	s.mu.Unlock()
 
 	return nil
 }
@@ -1,7 +1,14 @@
 package twitterscraper_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
 	"testing"
+	"time"
+
+	twitterscraper "github.com/einys/twitter-scraper"
 )
 
 func TestGetGuestToken(t *testing.T) {
@@ -24,3 +31,39 @@ func TestClearGuestToken(t *testing.T) {
 		t.Error("Expected empty guestToken")
 	}
 }
+
+func TestRequestContextCanceled(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	scraper := twitterscraper.New().WithBaseURL(server.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := scraper.GetProfileContext(ctx, "X")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestDelayContextCanceled(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"user":{"result":{"rest_id":"783214","legacy":{"screen_name":"X","name":"X"}}}}}`)
+	})
+	scraper := twitterscraper.New().WithBaseURL(server.URL).WithDelay(60)
+
+	if _, err := scraper.GetProfile("X"); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := scraper.GetProfileContext(ctx, "X")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Error("Expected delay to be interrupted by context")
+	}
+}
@@ -2,20 +2,17 @@ package twitterscraper
 
 import (
 	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha1"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
-	"net/http/cookiejar"
 	"net/url"
 	"strconv"
 	"strings"
 	"time"
-
-	"math/rand"
 )
 
 const (
@@ -24,7 +21,7 @@ const (
 	oAuthURL  = "https://api.twitter.com/oauth2/token"
 	// Doesn't require x-client-transaction-id header in auth. x-rate-limit-limit: 2000
 	bearerToken1 = "AAAAAAAAAAAAAAAAAAAAAFQODgEAAAAAVHTp76lzh3rFzcHbmHVvQxYYpTw%3DckAlMINMjmCwxUcaXbAN4XqJVdgMJaHqNOFgPMK0zN1qLqLQCF"
-	// HOTFIX: Returns 404 error; Requires x-client-transaction-id header in auth.
+	// HOTFIX: Returns 404 error; Requires x-client-transaction-id header in auth, see LoadClientTransaction.
 	// bearerToken2      = "AAAAAAAAAAAAAAAAAAAAANRILgAAAAAAnNwIzUejRCOuH5E6I8xnZz4puTs%3D1Zv7ttfk8LF81IUq16cHjhLTvJu4FA33AGWWjCpTnA"
 	bearerToken2      = "AAAAAAAAAAAAAAAAAAAAAFQODgEAAAAAVHTp76lzh3rFzcHbmHVvQxYYpTw%3DckAlMINMjmCwxUcaXbAN4XqJVdgMJaHqNOFgPMK0zN1qLqLQCF"
 	appConsumerKey    = "3nVuSoBZnx6U4vzUxf5w"
@@ -38,60 +35,77 @@ type (
 	}
 
 	flow struct {
-		Errors []struct {
-			Code    int    `json:"code"`
-			Message string `json:"message"`
-		} `json:"errors"`
-		FlowToken string `json:"flow_token"`
-		Status    string `json:"status"`
-		Subtasks  []struct {
-			SubtaskID   string      `json:"subtask_id"`
-			OpenAccount OpenAccount `json:"open_account"`
-		} `json:"subtasks"`
+		Errors    []ErrorDetail `json:"errors"`
+		FlowToken string        `json:"flow_token"`
+		Status    string        `json:"status"`
+		Subtasks  []flowSubtask `json:"subtasks"`
 	}
 
-	verifyCredentials struct {
-		Errors []struct {
-			Code    int    `json:"code"`
-			Message string `json:"message"`
-		} `json:"errors"`
+	flowSubtask struct {
+		SubtaskID   string      `json:"subtask_id"`
+		OpenAccount OpenAccount `json:"open_account"`
+		EnterText   *struct {
+			HintText      string   `json:"hint_text"`
+			KeyboardType  string   `json:"keyboard_type"`
+			PrimaryText   flowText `json:"primary_text"`
+			SecondaryText flowText `json:"secondary_text"`
+		} `json:"enter_text"`
+		Cta *struct {
+			PrimaryText   flowText `json:"primary_text"`
+			SecondaryText flowText `json:"secondary_text"`
+		} `json:"cta"`
 	}
-)
 
-func (s *Scraper) getAccessToken(consumerKey, consumerSecret string) (string, error) {
-	req, err := http.NewRequest("POST", oAuthURL, strings.NewReader("grant_type=client_credentials"))
-	if err != nil {
-		return "", err
+	flowText struct {
+		Text string `json:"text"`
 	}
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	req.SetBasicAuth(consumerKey, consumerSecret)
 
-	res, err := s.client.Do(req)
-	if err != nil {
-		return "", err
+	verifyCredentials struct {
+		Errors []ErrorDetail `json:"errors"`
 	}
-	defer res.Body.Close()
+)
 
-	if res.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(res.Body)
-		return "", fmt.Errorf("unexpected status code: %d, body: %s", res.StatusCode, body)
+func (s *Scraper) getAccessToken(ctx context.Context, consumerKey, consumerSecret string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", oAuthURL, strings.NewReader("grant_type=client_credentials"))
+	if err != nil {
+		return "", err
 	}
+	req.Header.Set("X-Client-Transaction-Id", s.transactionID("POST", req.URL.Path))
 
 	var a struct {
 		AccessToken string `json:"access_token"`
 	}
-	if err := json.NewDecoder(res.Body).Decode(&a); err != nil {
+	prepare := func(req *http.Request) error {
+		if err := s.resolveRequest(req); err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetBasicAuth(consumerKey, consumerSecret)
+		return nil
+	}
+	err = s.do(req, prepare, func(res *http.Response, body []byte) error {
+		if res.StatusCode != http.StatusOK {
+			return newAPIError(res, body)
+		}
+		return json.Unmarshal(body, &a)
+	})
+	if err != nil {
 		return "", err
 	}
 	return a.AccessToken, nil
 }
 
-func (s *Scraper) getFlow(data map[string]interface{}) (*flow, error) {
+func (s *Scraper) getFlow(ctx context.Context, data map[string]interface{}) (*flow, error) {
+	s.mu.RLock()
+	bearer := s.bearerToken
+	guest := s.guestTokens[bearer].value
+	userAgent := s.userAgent
+	s.mu.RUnlock()
 	headers := http.Header{
-		"Authorization":             []string{"Bearer " + s.bearerToken},
+		"Authorization":             []string{"Bearer " + bearer},
 		"Content-Type":              []string{"application/json"},
-		"User-Agent":                []string{s.userAgent},
-		"X-Guest-Token":             []string{s.guestToken},
+		"User-Agent":                []string{userAgent},
+		"X-Guest-Token":             []string{guest},
 		"X-Twitter-Auth-Type":       []string{"OAuth2Client"},
 		"X-Twitter-Active-User":     []string{"yes"},
 		"X-Twitter-Client-Language": []string{"en"},
@@ -101,21 +115,32 @@ func (s *Scraper) getFlow(data map[string]interface{}) (*flow, error) {
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest("POST", loginURL, bytes.NewReader(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", loginURL, bytes.NewReader(jsonData))
 	if err != nil {
 		return nil, err
 	}
-	req.Header = headers
-	s.setCSRFToken(req)
-
-	resp, err := s.client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	req.Header.Set("X-Client-Transaction-Id", s.transactionID("POST", req.URL.Path))
 
 	var info flow
-	err = json.NewDecoder(resp.Body).Decode(&info)
+	prepare := func(req *http.Request) error {
+		if err := s.resolveRequest(req); err != nil {
+			return err
+		}
+		for key, values := range headers {
+			req.Header[key] = values
+		}
+		s.setCSRFToken(req)
+		return nil
+	}
+	err = s.do(req, prepare, func(resp *http.Response, body []byte) error {
+		if err := json.Unmarshal(body, &info); err != nil {
+			return err
+		}
+		if len(info.Errors) > 0 {
+			return &APIError{StatusCode: resp.StatusCode, Errors: info.Errors}
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -123,25 +148,16 @@ func (s *Scraper) getFlow(data map[string]interface{}) (*flow, error) {
 	return &info, nil
 }
 
-func (s *Scraper) getFlowToken(data map[string]interface{}) (string, error) {
-	info, err := s.getFlow(data)
+func (s *Scraper) getFlowToken(ctx context.Context, data map[string]interface{}) (string, error) {
+	info, err := s.getFlow(ctx, data)
 	if err != nil {
 		return "", err
 	}
 
-	if len(info.Errors) > 0 {
-		return "", fmt.Errorf("auth error (%d): %v", info.Errors[0].Code, info.Errors[0].Message)
-	}
-
 	if len(info.Subtasks) > 0 {
-		if info.Subtasks[0].SubtaskID == "LoginEnterAlternateIdentifierSubtask" {
-			err = fmt.Errorf("auth error: %v", "LoginEnterAlternateIdentifierSubtask")
-		} else if info.Subtasks[0].SubtaskID == "LoginAcid" {
-			err = fmt.Errorf("auth error: %v", "LoginAcid")
-		} else if info.Subtasks[0].SubtaskID == "LoginTwoFactorAuthChallenge" {
-			err = fmt.Errorf("auth error: %v", "LoginTwoFactorAuthChallenge")
-		} else if info.Subtasks[0].SubtaskID == "DenyLoginSubtask" {
-			err = fmt.Errorf("auth error: %v", "DenyLoginSubtask")
+		switch info.Subtasks[0].SubtaskID {
+		case "LoginEnterAlternateIdentifierSubtask", "LoginAcid", "LoginTwoFactorAuthChallenge", "DenyLoginSubtask":
+			err = &ChallengeRequiredError{SubtaskID: info.Subtasks[0].SubtaskID, FlowToken: info.FlowToken}
 		}
 	}
 
@@ -150,34 +166,51 @@ func (s *Scraper) getFlowToken(data map[string]interface{}) (string, error) {
 
 // IsLoggedIn check if scraper logged in
 func (s *Scraper) IsLoggedIn() bool {
-	s.isLogged = true
-	s.setBearerToken(bearerToken1)
-	req, err := http.NewRequest("GET", "https://api.twitter.com/1.1/account/verify_credentials.json", nil)
+	return s.IsLoggedInContext(context.Background())
+}
+
+// IsLoggedInContext same as IsLoggedIn, but accepts context for cancellation.
+func (s *Scraper) IsLoggedInContext(ctx context.Context) bool {
+	// verify with the session bearer without touching the state other calls may be using
+	ctx = contextAsLoggedIn(ContextWithBearerToken(ctx, bearerToken1))
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.twitter.com/1.1/account/verify_credentials.json", nil)
 	if err != nil {
 		return false
 	}
+	req.Header.Set("X-Client-Transaction-Id", s.transactionID("GET", req.URL.Path))
 	var verify verifyCredentials
 	err = s.RequestAPI(req, &verify)
-	if err != nil || verify.Errors != nil {
-		s.isLogged = false
-		s.setBearerToken(bearerToken)
+	loggedIn := err == nil && verify.Errors == nil
+
+	s.mu.Lock()
+	s.isLogged = loggedIn
+	if loggedIn {
+		s.bearerToken = bearerToken1
 	} else {
-		s.isLogged = true
+		s.bearerToken = bearerToken
+	}
+	s.mu.Unlock()
+	if loggedIn {
+		s.autoSaveSession()
 	}
-	return s.isLogged
+	return loggedIn
 }
 
-// randomDelay introduces a random delay between 1 and 3 seconds
-func randomDelay() {
-	delay := time.Duration(3000+rand.Intn(5000)) * time.Millisecond
-	time.Sleep(delay)
-}
+// maxLoginSteps stops login flows going round in circles
+const maxLoginSteps = 20
 
 // Login to Twitter
 // Use Login(username, password) for ordinary login
 // or Login(username, password, email) for login if you have email confirmation
-// or Login(username, password, code_for_2FA) for login if you have two-factor authentication
+// or Login(username, password, phone) for login if you have phone confirmation
+// or Login(username, password, code_for_2FA) for login if you have two-factor authentication.
+// Other challenges are answered by handlers added with WithChallengeHandler.
 func (s *Scraper) Login(credentials ...string) error {
+	return s.LoginContext(context.Background(), credentials...)
+}
+
+// LoginContext same as Login, but accepts context for cancellation.
+func (s *Scraper) LoginContext(ctx context.Context, credentials ...string) error {
 	var username, password, confirmation string
 	if len(credentials) < 2 || len(credentials) > 3 {
 		return fmt.Errorf("invalid credentials")
@@ -190,13 +223,11 @@ func (s *Scraper) Login(credentials ...string) error {
 
 	s.setBearerToken(bearerToken2)
 
-	err := s.GetGuestToken()
+	err := s.GetGuestTokenContext(ctx)
 	if err != nil {
 		return err
 	}
 
-	randomDelay()
-
 	// flow start
 	data := map[string]interface{}{
 		"flow_name": "login",
@@ -207,131 +238,102 @@ func (s *Scraper) Login(credentials ...string) error {
 			},
 		},
 	}
-	flowToken, err := s.getFlowToken(data)
-	if err != nil {
-		return err
-	}
-
-	randomDelay()
-
-	// flow instrumentation step
-	data = map[string]interface{}{
-		"flow_token": flowToken,
-		"subtask_inputs": []map[string]interface{}{
-			{
-				"subtask_id":         "LoginJsInstrumentationSubtask",
-				"js_instrumentation": map[string]interface{}{"response": "{}", "link": "next_link"},
-			},
-		},
-	}
-	flowToken, err = s.getFlowToken(data)
-	if err != nil {
-		return err
-	}
-
-	randomDelay()
-
-	// flow username step
-	data = map[string]interface{}{
-		"flow_token": flowToken,
-		"subtask_inputs": []map[string]interface{}{
-			{
-				"subtask_id": "LoginEnterUserIdentifierSSO",
-				"settings_list": map[string]interface{}{
-					"setting_responses": []map[string]interface{}{
-						{
-							"key":           "user_identifier",
-							"response_data": map[string]interface{}{"text_data": map[string]interface{}{"result": username}},
-						},
-					},
-					"link": "next_link",
-				},
-			},
-		},
-	}
-	flowToken, err = s.getFlowToken(data)
-	if err != nil {
-		return err
-	}
-
-	randomDelay()
-
-	// flow password step
-	data = map[string]interface{}{
-		"flow_token": flowToken,
-		"subtask_inputs": []map[string]interface{}{
-			{
-				"subtask_id":     "LoginEnterPassword",
-				"enter_password": map[string]interface{}{"password": password, "link": "next_link"},
-			},
-		},
-	}
-	flowToken, err = s.getFlowToken(data)
-	if err != nil {
-		return err
-	}
-
-	randomDelay()
-
-	// flow duplication check
-	data = map[string]interface{}{
-		"flow_token": flowToken,
-		"subtask_inputs": []map[string]interface{}{
-			{
-				"subtask_id":              "AccountDuplicationCheck",
-				"check_logged_in_account": map[string]interface{}{"link": "AccountDuplicationCheck_false"},
-			},
-		},
-	}
-	flowToken, err = s.getFlowToken(data)
-	if err != nil {
-		var confirmationSubtask string
-		for _, subtask := range []string{"LoginAcid", "LoginTwoFactorAuthChallenge"} {
-			if strings.Contains(err.Error(), subtask) {
-				confirmationSubtask = subtask
-				break
-			}
+	attempts := make(map[string]int)
+	for step := 0; step < maxLoginSteps; step++ {
+		if err := s.loginDelay(ctx); err != nil {
+			return err
+		}
+		info, err := s.getFlow(ctx, data)
+		if err != nil {
+			return err
 		}
-		if confirmationSubtask != "" {
-			if confirmation == "" {
-				return fmt.Errorf("confirmation data required for %v", confirmationSubtask)
+		if len(info.Subtasks) == 0 {
+			if info.Status == "success" {
+				s.loginSucceeded()
+				return nil
 			}
+			return fmt.Errorf("login flow ended with status %q", info.Status)
+		}
 
-			randomDelay()
-
-			// flow confirmation
-			data = map[string]interface{}{
-				"flow_token": flowToken,
-				"subtask_inputs": []map[string]interface{}{
+		subtask := info.Subtasks[0]
+		input := map[string]interface{}{"subtask_id": subtask.SubtaskID}
+		switch subtask.SubtaskID {
+		case "LoginSuccessSubtask":
+			s.loginSucceeded()
+			return nil
+		case "LoginJsInstrumentationSubtask":
+			input["js_instrumentation"] = map[string]interface{}{"response": "{}", "link": "next_link"}
+		case "LoginEnterUserIdentifierSSO":
+			input["settings_list"] = map[string]interface{}{
+				"setting_responses": []map[string]interface{}{
 					{
-						"subtask_id": confirmationSubtask,
-						"enter_text": map[string]interface{}{"text": confirmation, "link": "next_link"},
+						"key":           "user_identifier",
+						"response_data": map[string]interface{}{"text_data": map[string]interface{}{"result": username}},
 					},
 				},
+				"link": "next_link",
+			}
+		case "LoginEnterPassword":
+			input["enter_password"] = map[string]interface{}{"password": password, "link": "next_link"}
+		case "AccountDuplicationCheck":
+			input["check_logged_in_account"] = map[string]interface{}{"link": "AccountDuplicationCheck_false"}
+		default:
+			challenge, ok := newChallenge(subtask)
+			if !ok {
+				return &UnsupportedSubtaskError{SubtaskID: subtask.SubtaskID, Message: subtask.message(), FlowToken: info.FlowToken}
 			}
-			_, err = s.getFlowToken(data)
-			if err != nil {
+			attempts[subtask.SubtaskID]++
+			challenge.Attempt = attempts[subtask.SubtaskID]
+
+			var answer string
+			if confirmation != "" && challenge.Attempt == 1 && (challenge.Kind == ChallengeEmail || challenge.Kind == ChallengePhone || challenge.Kind == ChallengeTwoFactor) {
+				answer = confirmation
+			} else if answer, err = s.answerChallenge(ctx, challenge, info.FlowToken); err != nil {
 				return err
 			}
-		} else {
-			return err
+			input["enter_text"] = map[string]interface{}{"text": answer, "link": "next_link"}
+		}
+		data = map[string]interface{}{
+			"flow_token":     info.FlowToken,
+			"subtask_inputs": []map[string]interface{}{input},
 		}
 	}
+	return fmt.Errorf("login flow not finished after %d steps", maxLoginSteps)
+}
 
+func (s *Scraper) loginSucceeded() {
+	s.mu.Lock()
 	s.isLogged = true
 	s.isOpenAccount = false
-	return nil
+	s.mu.Unlock()
+	s.autoSaveSession()
+}
+
+// message returns the text Twitter shows for the subtask.
+func (t flowSubtask) message() string {
+	if t.EnterText != nil && t.EnterText.PrimaryText.Text != "" {
+		return t.EnterText.PrimaryText.Text
+	}
+	if t.Cta != nil {
+		return strings.TrimSpace(t.Cta.PrimaryText.Text + " " + t.Cta.SecondaryText.Text)
+	}
+	return ""
 }
 
 // LoginOpenAccount as Twitter app
 func (s *Scraper) LoginOpenAccount() (OpenAccount, error) {
-	accessToken, err := s.getAccessToken(appConsumerKey, appConsumerSecret)
+	return s.LoginOpenAccountContext(context.Background())
+}
+
+// LoginOpenAccountContext same as LoginOpenAccount, but accepts context for cancellation.
+func (s *Scraper) LoginOpenAccountContext(ctx context.Context) (OpenAccount, error) {
+	accessToken, err := s.getAccessToken(ctx, appConsumerKey, appConsumerSecret)
 	if err != nil {
 		return OpenAccount{}, err
 	}
 	s.setBearerToken(accessToken)
 
-	err = s.GetGuestToken()
+	err = s.GetGuestTokenContext(ctx)
 	if err != nil {
 		return OpenAccount{}, err
 	}
@@ -346,7 +348,7 @@ func (s *Scraper) LoginOpenAccount() (OpenAccount, error) {
 			},
 		},
 	}
-	flowToken, err := s.getFlowToken(data)
+	flowToken, err := s.getFlowToken(ctx, data)
 	if err != nil {
 		return OpenAccount{}, err
 	}
@@ -360,75 +362,103 @@ func (s *Scraper) LoginOpenAccount() (OpenAccount, error) {
 			},
 		},
 	}
-	info, err := s.getFlow(data)
+	info, err := s.getFlow(ctx, data)
 	if err != nil {
 		return OpenAccount{}, err
 	}
 
 	if len(info.Subtasks) > 0 {
 		if info.Subtasks[0].SubtaskID == "OpenAccount" {
-			s.oAuthToken = info.Subtasks[0].OpenAccount.OAuthToken
-			s.oAuthSecret = info.Subtasks[0].OpenAccount.OAuthTokenSecret
-			if s.oAuthToken == "" || s.oAuthSecret == "" {
-				return OpenAccount{}, fmt.Errorf("auth error: %v", "Token or Secret is empty")
-			}
-			s.isLogged = true
-			s.isOpenAccount = true
-			return OpenAccount{
+			openAccount := OpenAccount{
 				OAuthToken:       info.Subtasks[0].OpenAccount.OAuthToken,
 				OAuthTokenSecret: info.Subtasks[0].OpenAccount.OAuthTokenSecret,
-			}, nil
+			}
+			if openAccount.OAuthToken == "" || openAccount.OAuthTokenSecret == "" {
+				return OpenAccount{}, fmt.Errorf("auth error: %v", "Token or Secret is empty")
+			}
+			s.WithOpenAccount(openAccount)
+			return openAccount, nil
 		}
 	}
 	return OpenAccount{}, fmt.Errorf("auth error: %v", "OpenAccount")
 }
 
 func (s *Scraper) WithOpenAccount(openAccount OpenAccount) {
+	s.mu.Lock()
 	s.oAuthToken = openAccount.OAuthToken
 	s.oAuthSecret = openAccount.OAuthTokenSecret
 	s.isLogged = true
 	s.isOpenAccount = true
+	s.mu.Unlock()
+	s.autoSaveSession()
 }
 
 // Logout is reset session
 func (s *Scraper) Logout() error {
-	req, err := http.NewRequest("POST", logoutURL, nil)
+	return s.LogoutContext(context.Background())
+}
+
+// LogoutContext same as Logout, but accepts context for cancellation.
+func (s *Scraper) LogoutContext(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", logoutURL, nil)
 	if err != nil {
 		return err
 	}
+	req.Header.Set("X-Client-Transaction-Id", s.transactionID("POST", req.URL.Path))
 	err = s.RequestAPI(req, nil)
 	if err != nil {
 		return err
 	}
 
+	s.mu.Lock()
 	s.isLogged = false
 	s.isOpenAccount = false
-	s.guestToken = ""
+	s.guestTokens = make(map[string]guestToken)
 	s.oAuthToken = ""
 	s.oAuthSecret = ""
-	s.client.Jar, _ = cookiejar.New(nil)
-	s.setBearerToken(bearerToken)
+	s.bearerToken = bearerToken
+	s.mu.Unlock()
+	s.jar.clear()
+	s.autoSaveSession()
 	return nil
 }
 
 func (s *Scraper) GetCookies() []*http.Cookie {
 	var cookies []*http.Cookie
-	for _, cookie := range s.client.Jar.Cookies(twURL) {
+	u := s.cookieURL()
+	for _, cookie := range s.jar.Cookies(u) {
 		if strings.Contains(cookie.Name, "guest") {
 			continue
 		}
-		cookie.Domain = twURL.Host
+		cookie.Domain = u.Hostname()
 		cookies = append(cookies, cookie)
 	}
 	return cookies
 }
 
 func (s *Scraper) SetCookies(cookies []*http.Cookie) {
-	s.client.Jar.SetCookies(twURL, cookies)
+	u := s.cookieURL()
+	host := u.Hostname()
+	_, known := siblingHost(host)
+	rewritten := make([]*http.Cookie, 0, len(cookies))
+	for _, cookie := range cookies {
+		c := *cookie
+		domain := strings.ToLower(strings.TrimPrefix(c.Domain, "."))
+		if !known {
+			// Cookies exported for twitter.com would be rejected by the jar for a custom endpoint
+			c.Domain = ""
+		} else if sibling, ok := siblingHost(domain); ok && domain != host && !strings.HasSuffix(domain, "."+host) {
+			// Cookies exported for x.com would be rejected by the jar for twitter.com and back
+			c.Domain = sibling
+		}
+		rewritten = append(rewritten, &c)
+	}
+	s.jar.SetCookies(u, rewritten)
+	s.autoSaveSession()
 }
 
 func (s *Scraper) ClearCookies() {
-	s.client.Jar, _ = cookiejar.New(nil)
+	s.jar.clear()
 }
 
 // Use auth_token cookie as Token and ct0 cookie as CSRFToken
@@ -471,15 +501,15 @@ func (s *Scraper) SetAuthToken(token AuthToken) {
 	s.SetCookies(cookies)
 }
 
-func (s *Scraper) sign(method string, ref *url.URL) string {
+func sign(oAuthToken, oAuthSecret, method string, ref *url.URL) string {
 	m := make(map[string]string)
 	m["oauth_consumer_key"] = appConsumerKey
 	m["oauth_nonce"] = "0"
 	m["oauth_signature_method"] = "HMAC-SHA1"
 	m["oauth_timestamp"] = strconv.FormatInt(time.Now().Unix(), 10)
-	m["oauth_token"] = s.oAuthToken
+	m["oauth_token"] = oAuthToken
 
-	key := []byte(appConsumerSecret + "&" + s.oAuthSecret)
+	key := []byte(appConsumerSecret + "&" + oAuthSecret)
 	h := hmac.New(sha1.New, key)
 
 	query := ref.Query()
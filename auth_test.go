@@ -21,6 +21,8 @@ var (
 	password      = os.Getenv("TWITTER_PASSWORD")
 	email         = os.Getenv("TWITTER_EMAIL")
 	skipAuthTest  = os.Getenv("SKIP_AUTH_TEST") != ""
+	cassetteMode  = os.Getenv("CASSETTE_MODE")
+	cassetteDir   = os.Getenv("CASSETTE_DIR")
 	testScraper   = newTestScraper(false)
 )
 
@@ -69,6 +71,16 @@ func newTestScraper(skip_auth bool) *twitterscraper.Scraper {
 		}
 	}
 
+	if cassetteDir == "" {
+		cassetteDir = testCassetteDir
+	}
+	switch cassetteMode {
+	case "record":
+		s.WithTransport(twitterscraper.NewCassette(cassetteDir, twitterscraper.CassetteRecord))
+	case "replay":
+		s.WithTransport(twitterscraper.NewCassette(cassetteDir, twitterscraper.CassetteReplay))
+	}
+
 	// Check connection by getting guest token
 	if err := s.GetGuestToken(); err != nil {
 		panic(fmt.Sprintf("cannot get guest token, can also be error with connection to twitter.\n %v", err))
@@ -2,63 +2,40 @@ package twitterscraper
 
 import (
 	"context"
-	"net/url"
 )
 
 // GetBookmarks returns channel with tweets from user bookmarks.
 func (s *Scraper) GetBookmarks(ctx context.Context, maxTweetsNbr int) <-chan *ScrappedTweetResult {
-	return getTweetTimeline(ctx, "", maxTweetsNbr, func(unused string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
-		return s.FetchBookmarks(maxTweetsNbr, cursor)
+	return s.getTweetTimeline(ctx, "", maxTweetsNbr, func(ctx context.Context, unused string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+		return s.FetchBookmarksContext(ctx, maxTweetsNbr, cursor)
 	})
 }
 
 // FetchBookmarks gets bookmarked tweets via the Twitter frontend GraphQL API.
 func (s *Scraper) FetchBookmarks(maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	return s.FetchBookmarksContext(context.Background(), maxTweetsNbr, cursor)
+}
+
+// FetchBookmarksContext same as FetchBookmarks, but accepts context for cancellation.
+func (s *Scraper) FetchBookmarksContext(ctx context.Context, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
 	if maxTweetsNbr > 200 {
 		maxTweetsNbr = 200
 	}
 
-	req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/-IyJFt9_jS_9d_vS3NN-fA/Bookmarks")
+	op := s.operation("Bookmarks")
+	req, err := s.newRequest(ctx, op.Method, op.URL())
 	if err != nil {
 		return nil, "", err
 	}
 
 	variables := map[string]interface{}{
-		"count":                  maxTweetsNbr,
-		"includePromotedContent": false,
+		"count": maxTweetsNbr,
 	}
-	features := map[string]interface{}{
-		"graphql_timeline_v2_bookmark_timeline":                                   true,
-		"responsive_web_graphql_exclude_directive_enabled":                        true,
-		"verified_phone_label_enabled":                                            false,
-		"creator_subscriptions_tweet_preview_api_enabled":                         true,
-		"responsive_web_graphql_timeline_navigation_enabled":                      true,
-		"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
-		"c9s_tweet_anatomy_moderator_badge_enabled":                               true,
-		"tweetypie_unmention_optimization_enabled":                                true,
-		"responsive_web_edit_tweet_api_enabled":                                   true,
-		"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
-		"view_counts_everywhere_api_enabled":                                      true,
-		"longform_notetweets_consumption_enabled":                                 true,
-		"responsive_web_twitter_article_tweet_consumption_enabled":                true,
-		"tweet_awards_web_tipping_enabled":                                        false,
-		"freedom_of_speech_not_reach_fetch_enabled":                               true,
-		"standardized_nudges_misinfo":                                             true,
-		"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": true,
-		"rweb_video_timestamps_enabled":                                           true,
-		"longform_notetweets_rich_text_read_enabled":                              true,
-		"longform_notetweets_inline_media_enabled":                                true,
-		"responsive_web_enhance_cards_enabled":                                    false,
-	}
-
 	if cursor != "" {
 		variables["cursor"] = cursor
 	}
 
-	query := url.Values{}
-	query.Set("variables", mapToJSONString(variables))
-	query.Set("features", mapToJSONString(features))
-	req.URL.RawQuery = query.Encode()
+	op.encode(req, variables)
 
 	var timeline bookmarksTimelineV2
 	err = s.RequestAPI(req, &timeline)
@@ -67,5 +44,5 @@ func (s *Scraper) FetchBookmarks(maxTweetsNbr int, cursor string) ([]*Tweet, str
 	}
 
 	tweets, nextCursor := timeline.parseTweets()
-	return tweets, nextCursor, nil
+	return s.localizeTweets(tweets), nextCursor, nil
 }
@@ -14,6 +14,7 @@ func TestGetBookmarks(t *testing.T) {
 	dupcheck := make(map[string]bool)
 	for tweet := range testScraper.GetBookmarks(context.Background(), maxTweetsNbr) {
 		if tweet.Error != nil {
+			skipIfNotRecorded(t, tweet.Error)
 			t.Error(tweet.Error)
 		} else {
 			count++
@@ -0,0 +1,97 @@
+package twitterscraper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cardBindingValue is a value of card binding, the field set depends on Type
+type cardBindingValue struct {
+	Type         string `json:"type"`
+	StringValue  string `json:"string_value"`
+	BooleanValue bool   `json:"boolean_value"`
+	ImageValue   *struct {
+		URL    string `json:"url"`
+		Width  int    `json:"width"`
+		Height int    `json:"height"`
+		Alt    string `json:"alt"`
+	} `json:"image_value"`
+}
+
+// legacyCard of legacy timelines, binding values are keyed by name
+type legacyCard struct {
+	Name          string                      `json:"name"`
+	URL           string                      `json:"url"`
+	BindingValues map[string]cardBindingValue `json:"binding_values"`
+}
+
+// parseCard returns the card and the poll it holds, if any.
+func parseCard(name, url string, values map[string]cardBindingValue) (*Card, *Poll) {
+	if name == "" {
+		return nil, nil
+	}
+	card := &Card{
+		Name:   name,
+		URL:    url,
+		Values: make(map[string]string),
+	}
+	for key, value := range values {
+		switch {
+		case value.ImageValue != nil:
+			if card.Images == nil {
+				card.Images = make(map[string]CardImage)
+			}
+			card.Images[key] = CardImage{
+				URL:    value.ImageValue.URL,
+				Width:  value.ImageValue.Width,
+				Height: value.ImageValue.Height,
+				Alt:    value.ImageValue.Alt,
+			}
+		case value.Type == "BOOLEAN":
+			card.Values[key] = strconv.FormatBool(value.BooleanValue)
+		default:
+			card.Values[key] = value.StringValue
+		}
+	}
+	card.Title = card.Values["title"]
+	card.Description = card.Values["description"]
+	card.Domain = card.Values["domain"]
+	if card.Domain == "" {
+		card.Domain = card.Values["vanity_url"]
+	}
+	if cardURL := card.Values["card_url"]; cardURL != "" {
+		card.URL = cardURL
+	}
+
+	if !strings.HasPrefix(name, "poll") {
+		return card, nil
+	}
+	poll := &Poll{
+		// card://1700000000000000000
+		ID:             strings.TrimPrefix(url, "card://"),
+		CountsAreFinal: card.Values["counts_are_final"] == "true",
+	}
+	for i := 1; ; i++ {
+		label, ok := card.Values[fmt.Sprintf("choice%d_label", i)]
+		if !ok {
+			break
+		}
+		count, _ := strconv.Atoi(card.Values[fmt.Sprintf("choice%d_count", i)])
+		poll.Choices = append(poll.Choices, PollChoice{Label: label, Count: count})
+	}
+	poll.DurationMinutes, _ = strconv.Atoi(card.Values["duration_minutes"])
+	if end, err := time.Parse(time.RFC3339, card.Values["end_datetime_utc"]); err == nil {
+		poll.EndDateTime = end
+	}
+	return card, poll
+}
+
+// parse returns the card of the legacy tweet and the poll it holds, if any.
+func (c *legacyCard) parse() (*Card, *Poll) {
+	if c == nil {
+		return nil, nil
+	}
+	return parseCard(c.Name, c.URL, c.BindingValues)
+}
@@ -0,0 +1,85 @@
+package twitterscraper_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/einys/twitter-scraper"
+)
+
+const pollCard = `{"rest_id":"card://1700000000000000001","legacy":{"name":"poll3choice_text_only","url":"card://1700000000000000001","binding_values":[
+	{"key":"choice1_label","value":{"type":"STRING","string_value":"Go"}},
+	{"key":"choice1_count","value":{"type":"STRING","string_value":"120"}},
+	{"key":"choice2_label","value":{"type":"STRING","string_value":"Rust"}},
+	{"key":"choice2_count","value":{"type":"STRING","string_value":"80"}},
+	{"key":"choice3_label","value":{"type":"STRING","string_value":"Zig"}},
+	{"key":"choice3_count","value":{"type":"STRING","string_value":"0"}},
+	{"key":"end_datetime_utc","value":{"type":"STRING","string_value":"2024-05-01T12:00:00Z"}},
+	{"key":"duration_minutes","value":{"type":"STRING","string_value":"1440"}},
+	{"key":"counts_are_final","value":{"type":"BOOLEAN","boolean_value":true}}
+]}}`
+
+const summaryCard = `{"name":"summary_large_image","url":"https://t.co/abc","binding_values":{
+	"title":{"type":"STRING","string_value":"Go 1.22 is released"},
+	"description":{"type":"STRING","string_value":"Release notes"},
+	"domain":{"type":"STRING","string_value":"go.dev"},
+	"card_url":{"type":"STRING","string_value":"https://t.co/abc"},
+	"thumbnail_image_large":{"type":"IMAGE","image_value":{"url":"https://pbs.twimg.com/card_img/1.jpg","width":800,"height":419,"alt":"gopher"}}
+}}`
+
+func TestTweetCard(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/TweetResultByRestId"):
+			fmt.Fprintf(w, `{"data":{"tweetResult":{"result":{"__typename":"Tweet","rest_id":"1","core":{"user_results":{"result":{"core":{"screen_name":"X","name":"X"}}}},"card":%s,"legacy":{"id_str":"1","full_text":"vote"}}}}}`, pollCard)
+		case strings.HasSuffix(r.URL.Path, "/timeline/conversation/1.json"):
+			fmt.Fprintf(w, `{"globalObjects":{"tweets":{"1":{"id_str":"1","full_text":"read","user_id_str":"1","card":%s}},"users":{"1":{"screen_name":"X","name":"X"}}},"timeline":{"instructions":[{"addEntries":{"entries":[{"content":{"item":{"content":{"tweet":{"id":"1"}}}}}]}}]}}`, summaryCard)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	scraper := twitterscraper.New().WithBaseURL(server.URL)
+	tweet, err := scraper.GetTweet("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	poll := tweet.Poll
+	if poll == nil {
+		t.Fatal("Expected poll")
+	}
+	var choices []string
+	for _, choice := range poll.Choices {
+		choices = append(choices, fmt.Sprintf("%s:%d", choice.Label, choice.Count))
+	}
+	if poll.ID != "1700000000000000001" || strings.Join(choices, ",") != "Go:120,Rust:80,Zig:0" || poll.DurationMinutes != 1440 ||
+		!poll.CountsAreFinal || !poll.EndDateTime.Equal(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected poll %+v", poll)
+	}
+	if tweet.Card == nil || tweet.Card.Name != "poll3choice_text_only" {
+		t.Errorf("Expected poll card, got %+v", tweet.Card)
+	}
+
+	scraper.WithOpenAccount(twitterscraper.OpenAccount{OAuthToken: "token", OAuthTokenSecret: "secret"})
+	tweet, err = scraper.GetTweet("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	card := tweet.Card
+	if card == nil {
+		t.Fatal("Expected card of legacy tweet")
+	}
+	if tweet.Poll != nil {
+		t.Errorf("Expected no poll for summary card, got %+v", tweet.Poll)
+	}
+	if card.Name != "summary_large_image" || card.Title != "Go 1.22 is released" || card.Description != "Release notes" ||
+		card.Domain != "go.dev" || card.URL != "https://t.co/abc" {
+		t.Errorf("Unexpected card %+v", card)
+	}
+	if image := card.Images["thumbnail_image_large"]; image.URL != "https://pbs.twimg.com/card_img/1.jpg" || image.Width != 800 || image.Alt != "gopher" {
+		t.Errorf("Unexpected card image %+v", image)
+	}
+}
@@ -0,0 +1,267 @@
+package twitterscraper
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// CassetteMode type
+type CassetteMode int
+
+const (
+	// CassetteReplay - serve responses from cassette files, never touch the network
+	CassetteReplay CassetteMode = iota
+	// CassetteRecord - send requests to the network and save responses to cassette files
+	CassetteRecord
+)
+
+// ErrCassetteNotFound returned in replay mode when no cassette was recorded for a request.
+var ErrCassetteNotFound = errors.New("cassette not found")
+
+var reUnsafeFileName = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// Cassette is an http.RoundTripper that records responses to files on disk and replays them.
+// Cassettes are keyed by method, GraphQL operation name and variables, so they survive query ID changes.
+// Request headers and Set-Cookie response headers are never written to disk.
+type Cassette struct {
+	Dir  string
+	Mode CassetteMode
+	// Transport used in record mode, http.DefaultTransport if nil
+	Transport http.RoundTripper
+
+	mu sync.Mutex
+}
+
+type cassetteRequest struct {
+	Method    string          `json:"method"`
+	URL       string          `json:"url"`
+	Operation string          `json:"operation"`
+	Variables json.RawMessage `json:"variables,omitempty"`
+}
+
+type cassetteResponse struct {
+	StatusCode int             `json:"status_code"`
+	Header     http.Header     `json:"header,omitempty"`
+	Body       json.RawMessage `json:"body,omitempty"`
+	Text       string          `json:"text,omitempty"`
+}
+
+type cassetteFile struct {
+	Request  cassetteRequest  `json:"request"`
+	Response cassetteResponse `json:"response"`
+}
+
+// NewCassette creates a Cassette stored in dir.
+func NewCassette(dir string, mode CassetteMode) *Cassette {
+	return &Cassette{Dir: dir, Mode: mode}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	key := newCassetteRequest(req, body)
+	path := filepath.Join(c.Dir, key.fileName())
+
+	if c.Mode == CassetteReplay {
+		return c.replay(req, key, path)
+	}
+	return c.record(req, key, path)
+}
+
+func (c *Cassette) replay(req *http.Request, key cassetteRequest, path string) (*http.Response, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s %s (%s)", ErrCassetteNotFound, key.Method, key.Operation, path)
+		}
+		return nil, err
+	}
+
+	var file cassetteFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("cassette %s: %v", path, err)
+	}
+
+	body := []byte(file.Response.Body)
+	if file.Response.Text != "" {
+		body = []byte(file.Response.Text)
+	}
+
+	header := file.Response.Header
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", file.Response.StatusCode, http.StatusText(file.Response.StatusCode)),
+		StatusCode:    file.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func (c *Cassette) record(req *http.Request, key cassetteRequest, path string) (*http.Response, error) {
+	transport := c.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	file := cassetteFile{
+		Request: key,
+		Response: cassetteResponse{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+		},
+	}
+	file.Response.Header.Del("Set-Cookie")
+	if json.Valid(body) {
+		file.Response.Body = body
+	} else {
+		file.Response.Text = string(body)
+	}
+
+	content, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	err = os.MkdirAll(c.Dir, 0o755)
+	if err == nil {
+		err = os.WriteFile(path, content, 0o644)
+	}
+	c.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+func newCassetteRequest(req *http.Request, body []byte) cassetteRequest {
+	key := cassetteRequest{
+		Method:    req.Method,
+		URL:       req.URL.Scheme + "://" + req.URL.Host + req.URL.Path,
+		Operation: graphQLOperation(req.URL.Path),
+	}
+
+	variables := requestVariables(req, body)
+	if variables != "" {
+		key.Variables = canonicalJSON(variables)
+	} else if key.Operation == "" {
+		// REST endpoints are keyed by the path and the sorted query instead
+		key.Operation = strings.Trim(req.URL.Path, "/")
+		key.Variables = canonicalQuery(req.URL.Query())
+	}
+
+	return key
+}
+
+func (key cassetteRequest) fileName() string {
+	h := sha256.New()
+	h.Write([]byte(key.Method + " " + key.Operation + " "))
+	h.Write(key.Variables)
+	name := reUnsafeFileName.ReplaceAllString(strings.TrimSuffix(key.Operation, ".json"), "_")
+	return name + "-" + hex.EncodeToString(h.Sum(nil))[:16] + ".json"
+}
+
+// requestVariables returns GraphQL variables from the query or the JSON body of the request.
+func requestVariables(req *http.Request, body []byte) string {
+	if v := req.URL.Query().Get("variables"); v != "" {
+		return v
+	}
+	if len(body) > 0 {
+		var payload struct {
+			Variables json.RawMessage `json:"variables"`
+		}
+		if json.Unmarshal(body, &payload) == nil && len(payload.Variables) > 0 {
+			return string(payload.Variables)
+		}
+	}
+	return ""
+}
+
+// graphQLOperation returns operation name from `/graphql/{queryID}/{operation}` path.
+func graphQLOperation(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	for i, part := range parts {
+		if part == "graphql" && i+2 < len(parts) {
+			return parts[i+2]
+		}
+	}
+	return ""
+}
+
+func canonicalJSON(data string) json.RawMessage {
+	var v interface{}
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&v); err != nil {
+		b, _ := json.Marshal(data)
+		return b
+	}
+	// encoding/json sorts map keys
+	b, _ := json.Marshal(v)
+	return b
+}
+
+func canonicalQuery(query url.Values) json.RawMessage {
+	if len(query) == 0 {
+		return nil
+	}
+	stable := make(map[string][]string, len(query))
+	for k, v := range query {
+		if !strings.HasPrefix(k, "oauth_") {
+			stable[k] = v
+		}
+	}
+	// encoding/json sorts map keys
+	b, _ := json.Marshal(stable)
+	return b
+}
@@ -0,0 +1,176 @@
+package twitterscraper_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	twitterscraper "github.com/einys/twitter-scraper"
+)
+
+// testCassetteDir holds hand-written cassettes of TestCassette* tests, other tests skip in replay mode unless recorded
+const testCassetteDir = "testdata/cassettes"
+
+func newReplayScraper(t *testing.T, logged bool) *twitterscraper.Scraper {
+	scraper := twitterscraper.New().WithTransport(twitterscraper.NewCassette(testCassetteDir, twitterscraper.CassetteReplay))
+	if logged {
+		scraper.SetAuthToken(twitterscraper.AuthToken{Token: "token", CSRFToken: "csrf"})
+		if !scraper.IsLoggedIn() {
+			t.Fatal("Expected IsLoggedIn() = true")
+		}
+	}
+	return scraper
+}
+
+func TestCassetteUserTweets(t *testing.T) {
+	scraper := newReplayScraper(t, false)
+
+	tweets, cursor, err := scraper.FetchTweetsByUserID("44196397", 20, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cursor != "DAABCgABGZ5bottom" {
+		t.Errorf("Expected bottom cursor, got %q", cursor)
+	}
+	if len(tweets) != 4 {
+		t.Fatalf("Expected 4 tweets, got %d", len(tweets))
+	}
+
+	pinned := tweets[0]
+	if !pinned.IsPin {
+		t.Error("Expected first tweet to be pinned")
+	}
+	if pinned.Username != "elonmusk" || pinned.PermanentURL != "https://twitter.com/elonmusk/status/1846000000000000001" {
+		t.Errorf("Unexpected author %q or url %q", pinned.Username, pinned.PermanentURL)
+	}
+	if len(pinned.Photos) != 1 || pinned.Photos[0].URL != "https://pbs.twimg.com/media/GZ1abcdXoAAhPZ1.jpg" {
+		t.Errorf("Unexpected photos %#v", pinned.Photos)
+	}
+	if len(pinned.Hashtags) != 1 || pinned.Hashtags[0] != "Starship" {
+		t.Errorf("Unexpected hashtags %#v", pinned.Hashtags)
+	}
+	if pinned.Views != 1000 {
+		t.Errorf("Expected 1000 views, got %d", pinned.Views)
+	}
+
+	if tweets[1].ID != "1845900000000000002" || len(tweets[1].Mentions) != 1 {
+		t.Errorf("Expected tweet with visibility results to be parsed, got %#v", tweets[1])
+	}
+	if !tweets[3].IsReply || tweets[3].InReplyToStatusID != tweets[2].ID {
+		t.Errorf("Expected module reply to point to %s, got %q", tweets[2].ID, tweets[3].InReplyToStatusID)
+	}
+}
+
+func TestCassetteTweetReplies(t *testing.T) {
+	scraper := newReplayScraper(t, false)
+
+	tweets, cursors, err := scraper.GetTweetReplies("1846100000000000010", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tweets) != 3 {
+		t.Fatalf("Expected 3 tweets, got %d", len(tweets))
+	}
+
+	focal := tweets[0]
+	if !focal.IsSelfThread || len(focal.Thread) != 1 || focal.Thread[0].ID != "1846100000000000011" {
+		t.Errorf("Expected self thread with one tweet, got %#v", focal.Thread)
+	}
+	if tweets[2].InReplyToStatus == nil || tweets[2].InReplyToStatus.ID != focal.ID {
+		t.Error("Expected reply to be linked to focal tweet")
+	}
+
+	if len(cursors) != 2 {
+		t.Fatalf("Expected 2 cursors, got %d", len(cursors))
+	}
+	if cursors[0].CursorType != "ShowMoreThreads" || cursors[0].ThreadID != "1846100000000000011" {
+		t.Errorf("Unexpected thread cursor %#v", cursors[0])
+	}
+	if cursors[1].CursorType != "Bottom" || cursors[1].Cursor != "PAAAAPAtPDwcbottom" {
+		t.Errorf("Unexpected bottom cursor %#v", cursors[1])
+	}
+}
+
+func TestCassetteSpace(t *testing.T) {
+	scraper := newReplayScraper(t, true)
+
+	space, err := scraper.GetSpace("1OdJrXPVLEnKX")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if space.ID != "1OdJrXPVLEnKX" || space.Title != "Recorded space" || space.State != "Ended" {
+		t.Errorf("Unexpected space %#v", space)
+	}
+	if len(space.Topics) != 1 || space.Topics[0].Title != "Technology" {
+		t.Errorf("Unexpected topics %#v", space.Topics)
+	}
+	participants := space.Participants
+	if participants.TotalCount != 1200 || participants.CurrentCount != 3 {
+		t.Errorf("Unexpected participants count %d/%d", participants.CurrentCount, participants.TotalCount)
+	}
+	if len(participants.Admins) != 1 || participants.Admins[0].Username != "elonmusk" || participants.Admins[0].UserID != "44196397" {
+		t.Errorf("Unexpected admins %#v", participants.Admins)
+	}
+	if len(participants.Speakers) != 1 || len(participants.Listeners) != 1 {
+		t.Errorf("Expected 1 speaker and 1 listener, got %d and %d", len(participants.Speakers), len(participants.Listeners))
+	}
+}
+
+func TestCassetteScheduledTweets(t *testing.T) {
+	scraper := newReplayScraper(t, true)
+
+	tweets, err := scraper.FetchScheduledTweets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tweets) != 2 {
+		t.Fatalf("Expected 2 scheduled tweets, got %d", len(tweets))
+	}
+	if tweets[0].ExecuteAt.Unix() != 1760000000 || tweets[0].State != "Scheduled" {
+		t.Errorf("Unexpected scheduling info %v %s", tweets[0].ExecuteAt, tweets[0].State)
+	}
+	if len(tweets[0].Photos) != 1 || tweets[0].Photos[0].ID != "1846300000000000100" {
+		t.Errorf("Unexpected photos %#v", tweets[0].Photos)
+	}
+	if len(tweets[0].Videos) != 1 || !strings.HasSuffix(tweets[0].Videos[0].URL, "/1280x720/high.mp4") {
+		t.Errorf("Expected highest bitrate video, got %#v", tweets[0].Videos)
+	}
+}
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"user":{"result":{"rest_id":"783214","legacy":{"screen_name":"X","name":"X"}}}}}`)
+	})
+
+	recorder := twitterscraper.New().WithBaseURL(server.URL).WithTransport(twitterscraper.NewCassette(dir, twitterscraper.CassetteRecord))
+	recorded, err := recorder.GetProfile("X")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.Close()
+
+	player := twitterscraper.New().WithBaseURL(server.URL).WithTransport(twitterscraper.NewCassette(dir, twitterscraper.CassetteReplay))
+	replayed, err := player.GetProfile("X")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replayed.UserID != recorded.UserID || replayed.Username != "X" {
+		t.Errorf("Expected replayed profile %#v, got %#v", recorded, replayed)
+	}
+
+	_, err = player.GetProfile("nobody")
+	if !errors.Is(err, twitterscraper.ErrCassetteNotFound) {
+		t.Errorf("Expected ErrCassetteNotFound, got %v", err)
+	}
+}
+
+// skipIfNotRecorded skips a test in replay mode when its cassette was never recorded.
+func skipIfNotRecorded(t *testing.T, err error) {
+	t.Helper()
+	if errors.Is(err, twitterscraper.ErrCassetteNotFound) {
+		t.Skipf("%v, record it with CASSETTE_MODE=record", err)
+	}
+}
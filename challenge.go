@@ -0,0 +1,150 @@
+package twitterscraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// ChallengeKind is the input a login challenge asks for.
+type ChallengeKind int
+
+const (
+	// ChallengeIdentifier - username, phone or email re-entry, `LoginEnterAlternateIdentifierSubtask`
+	ChallengeIdentifier ChallengeKind = iota
+	// ChallengeEmail - email address or the code sent to it, `LoginAcid`
+	ChallengeEmail
+	// ChallengePhone - phone number or the code sent to it, `LoginAcid` mentioning a phone
+	ChallengePhone
+	// ChallengeTwoFactor - code of the authenticator app, `LoginTwoFactorAuthChallenge`
+	ChallengeTwoFactor
+)
+
+func (k ChallengeKind) String() string {
+	switch k {
+	case ChallengeIdentifier:
+		return "identifier"
+	case ChallengeEmail:
+		return "email"
+	case ChallengePhone:
+		return "phone"
+	case ChallengeTwoFactor:
+		return "two factor"
+	}
+	return fmt.Sprintf("ChallengeKind(%d)", int(k))
+}
+
+// Challenge is a login subtask waiting for input from the user.
+type Challenge struct {
+	SubtaskID string
+	Kind      ChallengeKind
+	// Message shown by Twitter, like `Check your email`
+	Message string
+	// Hint of the input field, like `Confirmation code`
+	Hint string
+	// Attempt counts challenges of the same subtask in this login, starting at 1
+	Attempt int
+}
+
+// ChallengeHandler returns the answer to the login challenge.
+// Return ErrChallengeNotHandled to leave the challenge to the next handler.
+type ChallengeHandler func(ctx context.Context, challenge Challenge) (string, error)
+
+// ErrChallengeNotHandled is returned by a ChallengeHandler for challenges it doesn't answer.
+var ErrChallengeNotHandled = errors.New("challenge not handled")
+
+// UnsupportedSubtaskError returned by Login when Twitter asks for a subtask the scraper can't complete,
+// like `DenyLoginSubtask` or `ArkoseLogin` captcha.
+type UnsupportedSubtaskError struct {
+	SubtaskID string
+	// Message shown by Twitter, if any
+	Message string
+	// FlowToken to continue the login flow with
+	FlowToken string
+}
+
+func (e *UnsupportedSubtaskError) Error() string {
+	if e.Message == "" {
+		return "unsupported login subtask: " + e.SubtaskID
+	}
+	return fmt.Sprintf("unsupported login subtask: %s: %s", e.SubtaskID, e.Message)
+}
+
+// WithChallengeHandler add handler answering login challenges, like email confirmation or 2FA code.
+// Handlers are asked in the order they were added until one doesn't return ErrChallengeNotHandled.
+func (s *Scraper) WithChallengeHandler(handler ChallengeHandler) *Scraper {
+	s.mu.Lock()
+	s.challenges = append(s.challenges, handler)
+	s.mu.Unlock()
+	return s
+}
+
+// WithLoginDelay set bounds of random pauses between login steps, 3 to 8 seconds by default.
+func (s *Scraper) WithLoginDelay(min, max time.Duration) *Scraper {
+	s.mu.Lock()
+	s.loginDelayMin, s.loginDelayMax = min, max
+	s.mu.Unlock()
+	return s
+}
+
+// answerChallenge asks challenge handlers for the answer.
+func (s *Scraper) answerChallenge(ctx context.Context, challenge Challenge, flowToken string) (string, error) {
+	s.mu.RLock()
+	handlers := s.challenges
+	s.mu.RUnlock()
+	for _, handler := range handlers {
+		answer, err := handler(ctx, challenge)
+		if errors.Is(err, ErrChallengeNotHandled) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("%s challenge: %w", challenge.SubtaskID, err)
+		}
+		return answer, nil
+	}
+	return "", &ChallengeRequiredError{SubtaskID: challenge.SubtaskID, FlowToken: flowToken}
+}
+
+// newChallenge describes the subtask as a challenge, ok is false for subtasks without user input.
+func newChallenge(subtask flowSubtask) (Challenge, bool) {
+	challenge := Challenge{SubtaskID: subtask.SubtaskID}
+	if subtask.EnterText != nil {
+		challenge.Message = subtask.EnterText.PrimaryText.Text
+		challenge.Hint = subtask.EnterText.HintText
+	}
+	switch subtask.SubtaskID {
+	case "LoginEnterAlternateIdentifierSubtask":
+		challenge.Kind = ChallengeIdentifier
+	case "LoginAcid":
+		challenge.Kind = ChallengeEmail
+		if subtask.EnterText != nil {
+			text := strings.ToLower(subtask.EnterText.HintText + " " + subtask.EnterText.KeyboardType + " " + subtask.EnterText.SecondaryText.Text)
+			if strings.Contains(text, "phone") && !strings.Contains(text, "email") {
+				challenge.Kind = ChallengePhone
+			}
+		}
+	case "LoginTwoFactorAuthChallenge":
+		challenge.Kind = ChallengeTwoFactor
+	default:
+		return challenge, false
+	}
+	return challenge, true
+}
+
+// loginDelay pauses between login steps like a human filling the form.
+func (s *Scraper) loginDelay(ctx context.Context) error {
+	s.mu.RLock()
+	min, max := s.loginDelayMin, s.loginDelayMax
+	s.mu.RUnlock()
+	if max <= 0 {
+		return nil
+	}
+	delay := min
+	if max > min {
+		delay += time.Duration(rand.Int63n(int64(max - min)))
+	}
+	return sleepContext(ctx, delay)
+}
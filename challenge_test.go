@@ -0,0 +1,172 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	twitterscraper "github.com/einys/twitter-scraper"
+)
+
+// newLoginServer answers the login flow with the subtask next maps the completed subtask and its input text to.
+func newLoginServer(t *testing.T, next func(subtaskID, text string) string) *httptest.Server {
+	return newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/onboarding/task.json") {
+			http.NotFound(w, r)
+			return
+		}
+		var body struct {
+			SubtaskInputs []struct {
+				SubtaskID string `json:"subtask_id"`
+				EnterText struct {
+					Text string `json:"text"`
+				} `json:"enter_text"`
+			} `json:"subtask_inputs"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		var subtaskID, text string
+		if len(body.SubtaskInputs) > 0 {
+			subtaskID, text = body.SubtaskInputs[0].SubtaskID, body.SubtaskInputs[0].EnterText.Text
+		}
+		fmt.Fprintf(w, `{"flow_token":"flow","status":"success","subtasks":[%s]}`, next(subtaskID, text))
+	})
+}
+
+func TestLoginChallengeHandler(t *testing.T) {
+	server := newLoginServer(t, func(subtaskID, text string) string {
+		switch subtaskID {
+		case "":
+			return `{"subtask_id":"LoginJsInstrumentationSubtask"}`
+		case "LoginJsInstrumentationSubtask":
+			return `{"subtask_id":"LoginEnterUserIdentifierSSO"}`
+		case "LoginEnterUserIdentifierSSO":
+			return `{"subtask_id":"LoginEnterAlternateIdentifierSubtask","enter_text":{"primary_text":{"text":"Enter your phone number or username"},"hint_text":"Phone or username"}}`
+		case "LoginEnterAlternateIdentifierSubtask":
+			if text != "username" {
+				t.Errorf("Expected username, got %s", text)
+			}
+			return `{"subtask_id":"LoginEnterPassword"}`
+		case "LoginEnterPassword":
+			return `{"subtask_id":"AccountDuplicationCheck"}`
+		case "AccountDuplicationCheck":
+			return `{"subtask_id":"LoginAcid","enter_text":{"primary_text":{"text":"Check your email"},"hint_text":"Confirmation code"}}`
+		case "LoginAcid":
+			if text != "123456" {
+				return `{"subtask_id":"LoginAcid","enter_text":{"primary_text":{"text":"Check your email"},"hint_text":"Confirmation code"}}`
+			}
+			return `{"subtask_id":"LoginSuccessSubtask"}`
+		}
+		t.Errorf("Unexpected subtask %s", subtaskID)
+		return ""
+	})
+
+	var challenges []twitterscraper.Challenge
+	scraper := twitterscraper.New().WithBaseURL(server.URL).WithLoginDelay(0, 0).
+		WithChallengeHandler(func(ctx context.Context, challenge twitterscraper.Challenge) (string, error) {
+			if challenge.Kind != twitterscraper.ChallengeIdentifier {
+				return "", twitterscraper.ErrChallengeNotHandled
+			}
+			challenges = append(challenges, challenge)
+			return "username", nil
+		}).
+		WithChallengeHandler(func(ctx context.Context, challenge twitterscraper.Challenge) (string, error) {
+			challenges = append(challenges, challenge)
+			if challenge.Attempt == 1 {
+				return "wrong", nil
+			}
+			return "123456", nil
+		})
+	if err := scraper.Login("username", "password"); err != nil {
+		t.Fatal(err)
+	}
+
+	var kinds []string
+	for _, challenge := range challenges {
+		kinds = append(kinds, fmt.Sprintf("%s %d", challenge.Kind, challenge.Attempt))
+	}
+	if strings.Join(kinds, ",") != "identifier 1,email 1,email 2" {
+		t.Errorf("Unexpected challenges: %s", strings.Join(kinds, ","))
+	}
+	if challenges[1].Message != "Check your email" || challenges[1].Hint != "Confirmation code" {
+		t.Errorf("Unexpected challenge text: %+v", challenges[1])
+	}
+}
+
+func TestLoginConfirmation(t *testing.T) {
+	tests := []struct {
+		subtask      string
+		confirmation string
+	}{
+		{`{"subtask_id":"LoginTwoFactorAuthChallenge"}`, "654321"},
+		{`{"subtask_id":"LoginAcid","enter_text":{"primary_text":{"text":"Confirm your phone number"},"hint_text":"Phone number"}}`, "+15551234567"},
+	}
+	for _, test := range tests {
+		server := newLoginServer(t, func(subtaskID, text string) string {
+			switch subtaskID {
+			case "":
+				return test.subtask
+			case "LoginTwoFactorAuthChallenge", "LoginAcid":
+				if text != test.confirmation {
+					t.Errorf("Expected confirmation from credentials, got %s", text)
+				}
+			}
+			return ""
+		})
+		scraper := twitterscraper.New().WithBaseURL(server.URL).WithLoginDelay(0, 0)
+		if err := scraper.Login("username", "password", test.confirmation); err != nil {
+			t.Fatalf("%s: %v", test.subtask, err)
+		}
+	}
+}
+
+func TestLoginChallengeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		subtask string
+		check   func(err error) bool
+	}{
+		{
+			name:    "challenge without handler",
+			subtask: `{"subtask_id":"LoginEnterAlternateIdentifierSubtask"}`,
+			check: func(err error) bool {
+				var challenge *twitterscraper.ChallengeRequiredError
+				return errors.As(err, &challenge) && challenge.SubtaskID == "LoginEnterAlternateIdentifierSubtask"
+			},
+		},
+		{
+			name:    "denied login",
+			subtask: `{"subtask_id":"DenyLoginSubtask","cta":{"primary_text":{"text":"Could not log you in now."}}}`,
+			check: func(err error) bool {
+				var unsupported *twitterscraper.UnsupportedSubtaskError
+				return errors.As(err, &unsupported) && unsupported.SubtaskID == "DenyLoginSubtask" && unsupported.Message == "Could not log you in now."
+			},
+		},
+		{
+			name:    "arkose captcha",
+			subtask: `{"subtask_id":"ArkoseLogin"}`,
+			check: func(err error) bool {
+				var unsupported *twitterscraper.UnsupportedSubtaskError
+				return errors.As(err, &unsupported) && unsupported.SubtaskID == "ArkoseLogin"
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newLoginServer(t, func(subtaskID, text string) string { return test.subtask })
+			scraper := twitterscraper.New().WithBaseURL(server.URL).WithLoginDelay(0, 0)
+			if err := scraper.Login("username", "password"); !test.check(err) {
+				t.Errorf("Unexpected error %v", err)
+			}
+			if scraper.IsLoggedIn() {
+				t.Error("Expected scraper not to be logged in")
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/einys/twitter-scraper"
+)
+
+func TestConcurrentRequests(t *testing.T) {
+	var mu sync.Mutex
+	products := map[string]int{}
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/verify_credentials.json"):
+			fmt.Fprint(w, `{}`)
+		case strings.HasSuffix(r.URL.Path, "/SearchTimeline"):
+			var variables map[string]interface{}
+			if err := json.Unmarshal([]byte(r.URL.Query().Get("variables")), &variables); err != nil {
+				t.Error(err)
+			}
+			mu.Lock()
+			products[fmt.Sprint(variables["product"])]++
+			mu.Unlock()
+			fmt.Fprint(w, `{}`)
+		default:
+			fmt.Fprint(w, `{"data":{"user":{"result":{"rest_id":"783214","legacy":{"screen_name":"X","name":"X"}}}}}`)
+		}
+	})
+	scraper := twitterscraper.New().WithBaseURL(server.URL)
+	scraper.SetAuthToken(twitterscraper.AuthToken{Token: "auth", CSRFToken: "ct0"})
+	if !scraper.IsLoggedIn() {
+		t.Fatal("Expected scraper to be logged in")
+	}
+
+	modes := map[twitterscraper.SearchMode]string{
+		twitterscraper.SearchTop:    "Top",
+		twitterscraper.SearchLatest: "Latest",
+		twitterscraper.SearchPhotos: "Photos",
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		for mode := range modes {
+			wg.Add(1)
+			go func(mode twitterscraper.SearchMode) {
+				defer wg.Done()
+				ctx := twitterscraper.ContextWithSearchMode(context.Background(), mode)
+				if _, _, err := scraper.FetchSearchTweetsContext(ctx, "twitter", 20, ""); err != nil {
+					t.Error(err)
+				}
+			}(mode)
+		}
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, err := scraper.GetProfile("X"); err != nil {
+				t.Error(err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			scraper.SetSearchMode(twitterscraper.SearchVideos)
+			scraper.WithReplies(true)
+			scraper.SetUserAgent("test")
+			scraper.WithDelay(0)
+			scraper.GetCookies()
+		}()
+	}
+	wg.Wait()
+
+	for _, product := range modes {
+		if products[product] != 5 {
+			t.Errorf("Expected 5 searches with product %s, got %d", product, products[product])
+		}
+	}
+}
+
+func TestContextWithBearerToken(t *testing.T) {
+	var mu sync.Mutex
+	authorizations := map[string]int{}
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Guest-Token") != "1234567890" {
+			t.Errorf("Expected guest token, got %q", r.Header.Get("X-Guest-Token"))
+		}
+		mu.Lock()
+		authorizations[r.Header.Get("Authorization")]++
+		mu.Unlock()
+		fmt.Fprint(w, `{"data":{"user":{"result":{"rest_id":"783214","legacy":{"screen_name":"X","name":"X"}}}}}`)
+	})
+	scraper := twitterscraper.New().WithBaseURL(server.URL)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			ctx := twitterscraper.ContextWithBearerToken(context.Background(), "other")
+			if _, err := scraper.GetProfileContext(ctx, "X"); err != nil {
+				t.Error(err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := scraper.GetProfile("X"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if authorizations["Bearer other"] != 10 || len(authorizations) != 2 {
+		t.Errorf("Expected 10 requests with each bearer token, got %v", authorizations)
+	}
+	if !scraper.IsGuestToken() {
+		t.Error("Expected guest token for the scraper bearer token")
+	}
+}
+
+func TestConcurrentSetters(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"user":{"result":{"rest_id":"783214","legacy":{"screen_name":"X","name":"X"}}}}}`)
+	})
+	scraper := twitterscraper.New().WithBaseURL(server.URL)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, err := scraper.GetProfile("X"); err != nil {
+				t.Error(err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			scraper.WithRetry(twitterscraper.DefaultRetryPolicy)
+			scraper.WithRateLimitMode(twitterscraper.RateLimitFail)
+			scraper.WithBaseURL(server.URL)
+			scraper.WithTransport(http.DefaultTransport)
+			scraper.WithClientTimeout(time.Minute)
+			if err := scraper.SetProxy(""); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,236 @@
+package twitterscraper
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrMissingAuthCookies returned by cookie importers when `auth_token` or `ct0` is absent.
+var ErrMissingAuthCookies = errors.New("missing auth cookies")
+
+// authCookieNames are cookies a logged in session can't work without
+var authCookieNames = []string{"auth_token", "ct0"}
+
+// ParseNetscapeCookies reads cookies in the Netscape `cookies.txt` format, as exported by browser extensions,
+// curl and yt-dlp. Cookies of other domains than twitter.com and x.com are skipped.
+func ParseNetscapeCookies(r io.Reader) ([]*http.Cookie, error) {
+	var cookies []*http.Cookie
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		httpOnly := false
+		if strings.HasPrefix(line, "#HttpOnly_") {
+			line, httpOnly = strings.TrimPrefix(line, "#HttpOnly_"), true
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) == 6 {
+			// empty value
+			fields = append(fields, "")
+		}
+		if len(fields) != 7 {
+			return nil, fmt.Errorf("cookies.txt line %d: expected 7 tab separated fields, got %d", n, len(fields))
+		}
+		cookie := &http.Cookie{
+			Domain:   fields[0],
+			Path:     fields[2],
+			Secure:   strings.EqualFold(fields[3], "TRUE"),
+			HttpOnly: httpOnly,
+			Name:     fields[5],
+			Value:    fields[6],
+		}
+		if expires, err := strconv.ParseInt(fields[4], 10, 64); err == nil && expires > 0 {
+			cookie.Expires = time.Unix(expires, 0)
+		}
+		if isTwitterCookie(cookie) {
+			cookies = append(cookies, cookie)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return cookies, nil
+}
+
+// jsonCookie is a cookie exported by EditThisCookie, Cookie-Editor, Playwright `storageState` or encoded http.Cookie.
+type jsonCookie struct {
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Domain   string `json:"domain"`
+	Path     string `json:"path"`
+	Secure   bool   `json:"secure"`
+	HttpOnly bool   `json:"httpOnly"`
+	// ExpirationDate in unix seconds, fractional, by browser extensions
+	ExpirationDate float64 `json:"expirationDate"`
+	// Expires in unix seconds by Playwright or RFC 3339 time by encoded http.Cookie
+	Expires json.RawMessage `json:"expires"`
+}
+
+func (c jsonCookie) expires() time.Time {
+	if c.ExpirationDate > 0 {
+		sec, frac := math.Modf(c.ExpirationDate)
+		return time.Unix(int64(sec), int64(frac*1e9))
+	}
+	var seconds float64
+	if json.Unmarshal(c.Expires, &seconds) == nil && seconds > 0 {
+		return time.Unix(int64(seconds), 0)
+	}
+	var expires time.Time
+	if json.Unmarshal(c.Expires, &expires) == nil && expires.Year() > 1 {
+		return expires
+	}
+	return time.Time{}
+}
+
+// ParseJSONCookies reads cookies exported as JSON by browser extensions like EditThisCookie and Cookie-Editor.
+// Playwright `storageState` files and JSON encoded `[]*http.Cookie` are accepted too.
+// Cookies of other domains than twitter.com and x.com are skipped.
+func ParseJSONCookies(data []byte) ([]*http.Cookie, error) {
+	var exported []jsonCookie
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var state struct {
+			Cookies []jsonCookie `json:"cookies"`
+		}
+		if err := json.Unmarshal(data, &state); err != nil {
+			return nil, fmt.Errorf("invalid cookies JSON: %w", err)
+		}
+		exported = state.Cookies
+	} else if err := json.Unmarshal(data, &exported); err != nil {
+		return nil, fmt.Errorf("invalid cookies JSON: %w", err)
+	}
+
+	var cookies []*http.Cookie
+	for _, c := range exported {
+		cookie := &http.Cookie{
+			Name:     c.Name,
+			Value:    c.Value,
+			Domain:   c.Domain,
+			Path:     c.Path,
+			Secure:   c.Secure,
+			HttpOnly: c.HttpOnly,
+			Expires:  c.expires(),
+		}
+		if isTwitterCookie(cookie) {
+			cookies = append(cookies, cookie)
+		}
+	}
+	return cookies, nil
+}
+
+// ParseCookieHeader reads cookies from the value of `Cookie` header copied from browser dev tools,
+// with or without the `Cookie:` prefix.
+func ParseCookieHeader(header string) []*http.Cookie {
+	header = strings.TrimSpace(header)
+	if len(header) > 7 && strings.EqualFold(header[:7], "cookie:") {
+		header = strings.TrimSpace(header[7:])
+	}
+	req := http.Request{Header: http.Header{"Cookie": {header}}}
+	return req.Cookies()
+}
+
+// isTwitterCookie reports whether the cookie belongs to twitter.com or x.com, cookies without domain do.
+func isTwitterCookie(cookie *http.Cookie) bool {
+	domain := strings.ToLower(strings.TrimPrefix(cookie.Domain, "."))
+	if domain == "" {
+		return true
+	}
+	for _, d := range []string{"twitter.com", "x.com"} {
+		if domain == d || strings.HasSuffix(domain, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+// ImportCookies set cookies exported from a browser after checking `auth_token` and `ct0` are present.
+// Cookies of twitter.com and x.com are moved to the domain of the scraper, expired ones are skipped.
+func (s *Scraper) ImportCookies(cookies []*http.Cookie) error {
+	domain := s.cookieURL().Hostname()
+	now := time.Now()
+	byName := make(map[string]*http.Cookie)
+	var names []string
+	for _, cookie := range cookies {
+		if !isTwitterCookie(cookie) || (!cookie.Expires.IsZero() && cookie.Expires.Before(now)) {
+			continue
+		}
+		c := *cookie
+		original := strings.ToLower(strings.TrimPrefix(c.Domain, "."))
+		c.Domain = domain
+		if c.Path == "" {
+			c.Path = "/"
+		}
+		if previous, ok := byName[c.Name]; ok {
+			// the same cookie exported for both domains, the one of the scraper domain wins
+			if original != domain && previous.Value != "" {
+				continue
+			}
+		} else {
+			names = append(names, c.Name)
+		}
+		byName[c.Name] = &c
+	}
+
+	var missing []string
+	for _, name := range authCookieNames {
+		if cookie, ok := byName[name]; !ok || cookie.Value == "" {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrMissingAuthCookies, strings.Join(missing, ", "))
+	}
+
+	imported := make([]*http.Cookie, 0, len(names))
+	for _, name := range names {
+		imported = append(imported, byName[name])
+	}
+	s.SetCookies(imported)
+	return nil
+}
+
+// ImportNetscapeCookies set cookies from Netscape `cookies.txt`, see ParseNetscapeCookies and ImportCookies.
+func (s *Scraper) ImportNetscapeCookies(r io.Reader) error {
+	cookies, err := ParseNetscapeCookies(r)
+	if err != nil {
+		return err
+	}
+	return s.ImportCookies(cookies)
+}
+
+// ImportJSONCookies set cookies exported as JSON, see ParseJSONCookies and ImportCookies.
+func (s *Scraper) ImportJSONCookies(data []byte) error {
+	cookies, err := ParseJSONCookies(data)
+	if err != nil {
+		return err
+	}
+	return s.ImportCookies(cookies)
+}
+
+// ImportCookieHeader set cookies from `Cookie` header value, see ParseCookieHeader and ImportCookies.
+func (s *Scraper) ImportCookieHeader(header string) error {
+	return s.ImportCookies(ParseCookieHeader(header))
+}
+
+// LoadCookiesFile set cookies from a JSON or Netscape `cookies.txt` file, the format is detected by content.
+func (s *Scraper) LoadCookiesFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && (trimmed[0] == '[' || trimmed[0] == '{') {
+		return s.ImportJSONCookies(data)
+	}
+	return s.ImportNetscapeCookies(bytes.NewReader(data))
+}
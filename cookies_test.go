@@ -0,0 +1,145 @@
+package twitterscraper_test
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	twitterscraper "github.com/einys/twitter-scraper"
+)
+
+const netscapeCookies = `# Netscape HTTP Cookie File
+# This is a generated file! Do not edit.
+
+.x.com	TRUE	/	TRUE	4102444800	guest_id	v1%3A1700000000
+#HttpOnly_.x.com	TRUE	/	TRUE	4102444800	auth_token	xauth
+.x.com	TRUE	/	TRUE	4102444800	ct0	xct0
+.twitter.com	TRUE	/	TRUE	4102444800	auth_token	twauth
+.twitter.com	TRUE	/	TRUE	1000000000	kdt	expired
+.google.com	TRUE	/	FALSE	4102444800	NID	other
+`
+
+const jsonCookies = `[
+  {"domain": ".x.com", "expirationDate": 4102444800.5, "hostOnly": false, "httpOnly": true, "name": "auth_token", "path": "/", "sameSite": "no_restriction", "secure": true, "session": false, "value": "jsonauth"},
+  {"domain": ".x.com", "expirationDate": 4102444800, "hostOnly": false, "httpOnly": false, "name": "ct0", "path": "/", "secure": true, "session": false, "value": "jsonct0"},
+  {"domain": "www.google.com", "name": "NID", "path": "/", "value": "other"}
+]`
+
+func cookieValues(cookies []*http.Cookie) map[string]string {
+	values := make(map[string]string)
+	for _, cookie := range cookies {
+		values[cookie.Name] = cookie.Value
+	}
+	return values
+}
+
+func TestParseNetscapeCookies(t *testing.T) {
+	cookies, err := twitterscraper.ParseNetscapeCookies(strings.NewReader(netscapeCookies))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cookies) != 5 {
+		t.Fatalf("Expected 5 cookies of twitter.com and x.com, got %d", len(cookies))
+	}
+	if auth := cookies[1]; auth.Name != "auth_token" || auth.Value != "xauth" || !auth.HttpOnly || !auth.Secure || auth.Expires.Unix() != 4102444800 {
+		t.Errorf("Unexpected HttpOnly cookie %+v", auth)
+	}
+
+	if _, err := twitterscraper.ParseNetscapeCookies(strings.NewReader("x.com\tTRUE\t/\n")); err == nil {
+		t.Error("Expected error for malformed line")
+	}
+}
+
+func TestParseJSONCookies(t *testing.T) {
+	cookies, err := twitterscraper.ParseJSONCookies([]byte(jsonCookies))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values := cookieValues(cookies); len(values) != 2 || values["auth_token"] != "jsonauth" || values["ct0"] != "jsonct0" {
+		t.Errorf("Unexpected cookies %v", values)
+	}
+	if cookies[0].Expires.Unix() != 4102444800 || !cookies[0].HttpOnly {
+		t.Errorf("Unexpected cookie %+v", cookies[0])
+	}
+
+	state := `{"cookies":[{"name":"auth_token","value":"pw","domain":".x.com","path":"/","expires":4102444800}],"origins":[]}`
+	cookies, err = twitterscraper.ParseJSONCookies([]byte(state))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cookies) != 1 || cookies[0].Value != "pw" || cookies[0].Expires.Unix() != 4102444800 {
+		t.Errorf("Unexpected storage state cookies %+v", cookies)
+	}
+
+	if _, err := twitterscraper.ParseJSONCookies([]byte("not json")); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+}
+
+func TestParseCookieHeader(t *testing.T) {
+	for _, header := range []string{
+		"auth_token=hauth; ct0=hct0; lang=en",
+		"Cookie: auth_token=hauth; ct0=hct0; lang=en",
+	} {
+		values := cookieValues(twitterscraper.ParseCookieHeader(header))
+		if len(values) != 3 || values["auth_token"] != "hauth" || values["ct0"] != "hct0" {
+			t.Errorf("Unexpected cookies of %q: %v", header, values)
+		}
+	}
+}
+
+func TestImportCookies(t *testing.T) {
+	scraper := twitterscraper.New()
+	if err := scraper.ImportNetscapeCookies(strings.NewReader(netscapeCookies)); err != nil {
+		t.Fatal(err)
+	}
+	values := cookieValues(scraper.GetCookies())
+	if values["auth_token"] != "twauth" || values["ct0"] != "xct0" {
+		t.Errorf("Expected x.com cookies moved to twitter.com preferring its own, got %v", values)
+	}
+	if _, ok := values["kdt"]; ok {
+		t.Error("Expected expired cookie to be skipped")
+	}
+	if _, ok := values["NID"]; ok {
+		t.Error("Expected cookie of other domain to be skipped")
+	}
+
+	scraper = twitterscraper.New()
+	if err := scraper.ImportCookieHeader("Cookie: auth_token=hauth; ct0=hct0"); err != nil {
+		t.Fatal(err)
+	}
+	if values := cookieValues(scraper.GetCookies()); values["auth_token"] != "hauth" || values["ct0"] != "hct0" {
+		t.Errorf("Unexpected cookies %v", values)
+	}
+}
+
+func TestImportCookiesMissing(t *testing.T) {
+	scraper := twitterscraper.New()
+	err := scraper.ImportCookieHeader("auth_token=hauth; lang=en")
+	if !errors.Is(err, twitterscraper.ErrMissingAuthCookies) || !strings.Contains(err.Error(), "ct0") {
+		t.Errorf("Expected missing ct0 error, got %v", err)
+	}
+	if len(scraper.GetCookies()) != 0 {
+		t.Error("Expected no cookies to be set")
+	}
+}
+
+func TestLoadCookiesFile(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{"cookies.json": jsonCookies, "cookies.txt": netscapeCookies} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		scraper := twitterscraper.New()
+		if err := scraper.LoadCookiesFile(path); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+		if values := cookieValues(scraper.GetCookies()); values["auth_token"] == "" || values["ct0"] == "" {
+			t.Errorf("%s: unexpected cookies %v", name, values)
+		}
+	}
+}
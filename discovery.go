@@ -0,0 +1,207 @@
+package twitterscraper
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// reBundleScript matches main bundle in the page, like `https://abs.twimg.com/responsive-web/client-web/main.8a3d0e5a.js`
+	reBundleScript = regexp.MustCompile(`<script[^>]+src="([^"]*/main\.[\w-]+\.js)"`)
+	// reBundleOperation matches operation definitions in the bundle, like
+	// `{queryId:"V7H0Ap3_Hh2FyS75OCDO3Q",operationName:"UserTweets",operationType:"query",metadata:{featureSwitches:[...],fieldToggles:[...]}}`
+	reBundleOperation = regexp.MustCompile(`queryId:"([\w-]+)",operationName:"(\w+)",operationType:"(\w+)",metadata:\{([^{}]*)\}`)
+	reFeatureSwitches = regexp.MustCompile(`featureSwitches:\[([^\]]*)\]`)
+	reFieldToggles    = regexp.MustCompile(`fieldToggles:\[([^\]]*)\]`)
+	reQuotedName      = regexp.MustCompile(`"(\w+)"`)
+	// reFeatureValue matches boolean feature switches of `window.__INITIAL_STATE__` in the page, like `"rweb_video_timestamps_enabled":{"value":true}`
+	reFeatureValue = regexp.MustCompile(`"(\w+)":\{"value":(true|false)\}`)
+)
+
+// DiscoveredOperations holds operations the web client currently uses, see ParseBundles.
+type DiscoveredOperations struct {
+	// Operations keyed by name, Features and FieldToggles list every switch the operation expects
+	Operations map[string]Operation
+	// FeatureValues are default feature switch values found in the page
+	FeatureValues map[string]bool
+}
+
+// BundleURLs returns URLs of main.*.js bundles referenced in the page HTML, resolved against pageURL.
+func BundleURLs(html []byte, pageURL string) []string {
+	base, _ := url.Parse(pageURL)
+	var urls []string
+	for _, match := range reBundleScript.FindAllSubmatch(html, -1) {
+		src := string(match[1])
+		if base != nil {
+			if ref, err := base.Parse(src); err == nil {
+				src = ref.String()
+			}
+		}
+		urls = append(urls, src)
+	}
+	return urls
+}
+
+// ParseBundles extracts queryId, operationName and featureSwitches of every operation from the page HTML
+// and its main.*.js bundles. The page is optional, without it feature values are unknown.
+func ParseBundles(html []byte, bundles ...[]byte) *DiscoveredOperations {
+	discovered := &DiscoveredOperations{
+		Operations:    make(map[string]Operation),
+		FeatureValues: make(map[string]bool),
+	}
+	for _, match := range reFeatureValue.FindAllSubmatch(html, -1) {
+		discovered.FeatureValues[string(match[1])], _ = strconv.ParseBool(string(match[2]))
+	}
+
+	for _, bundle := range bundles {
+		for _, match := range reBundleOperation.FindAllSubmatch(bundle, -1) {
+			op := Operation{
+				Name:    string(match[2]),
+				QueryID: string(match[1]),
+				Method:  "GET",
+			}
+			if string(match[3]) == "mutation" {
+				op.Method = "POST"
+			}
+			if m := reFeatureSwitches.FindSubmatch(match[4]); m != nil {
+				op.Features = switchNames(m[1])
+			}
+			if m := reFieldToggles.FindSubmatch(match[4]); m != nil {
+				op.FieldToggles = switchNames(m[1])
+			}
+			discovered.Operations[op.Name] = op
+		}
+	}
+	return discovered
+}
+
+// switchNames returns map of quoted names in the list with false values.
+func switchNames(list []byte) map[string]interface{} {
+	names := make(map[string]interface{})
+	for _, match := range reQuotedName.FindAllSubmatch(list, -1) {
+		names[string(match[1])] = false
+	}
+	return names
+}
+
+// Apply replace query IDs of the registry with discovered ones and adds unknown operations.
+// Features and field toggles are replaced by the discovered switches, with values taken from the page,
+// then from the registry, false otherwise. Methods and variables of known operations are kept.
+func (d *DiscoveredOperations) Apply(r *OperationRegistry) {
+	for name, discovered := range d.Operations {
+		r.update(name, func(op Operation, ok bool) Operation {
+			if !ok {
+				op = Operation{Name: name, Method: discovered.Method}
+			}
+			op.QueryID = discovered.QueryID
+			op.Features = d.switchValues(discovered.Features, op.Features, true)
+			op.FieldToggles = d.switchValues(discovered.FieldToggles, op.FieldToggles, false)
+			return op
+		})
+	}
+}
+
+func (d *DiscoveredOperations) switchValues(names, current map[string]interface{}, fromPage bool) map[string]interface{} {
+	if len(names) == 0 {
+		return current
+	}
+	values := make(map[string]interface{}, len(names))
+	for name := range names {
+		if value, ok := d.FeatureValues[name]; ok && fromPage {
+			values[name] = value
+		} else if value, ok := current[name]; ok {
+			values[name] = value
+		} else {
+			values[name] = false
+		}
+	}
+	return values
+}
+
+// LoadBundleFiles update the registry from saved page and main.*.js bundle files, see ParseBundles.
+// The page file is optional, pass empty string to skip it.
+func (r *OperationRegistry) LoadBundleFiles(pageFile string, bundleFiles ...string) error {
+	var html []byte
+	if pageFile != "" {
+		var err error
+		if html, err = os.ReadFile(pageFile); err != nil {
+			return err
+		}
+	}
+	bundles := make([][]byte, 0, len(bundleFiles))
+	for _, file := range bundleFiles {
+		bundle, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		bundles = append(bundles, bundle)
+	}
+	discovered := ParseBundles(html, bundles...)
+	if len(discovered.Operations) == 0 {
+		return fmt.Errorf("no operations found in %s", strings.Join(bundleFiles, ", "))
+	}
+	discovered.Apply(r)
+	return nil
+}
+
+// DiscoverOperations fetch the main page and its main.*.js bundles and update the operations registry
+// with query IDs and features the web client currently uses.
+func (s *Scraper) DiscoverOperations() (*DiscoveredOperations, error) {
+	return s.DiscoverOperationsContext(context.Background())
+}
+
+// DiscoverOperationsContext same as DiscoverOperations, but accepts context for cancellation.
+func (s *Scraper) DiscoverOperationsContext(ctx context.Context) (*DiscoveredOperations, error) {
+	pageURL := s.cookieURL().String()
+	html, err := s.fetchPage(ctx, pageURL)
+	if err != nil {
+		return nil, err
+	}
+	bundleURLs := BundleURLs(html, pageURL)
+	if len(bundleURLs) == 0 {
+		return nil, fmt.Errorf("no main bundle found in %s", pageURL)
+	}
+	bundles := make([][]byte, 0, len(bundleURLs))
+	for _, bundleURL := range bundleURLs {
+		bundle, err := s.fetchPage(ctx, bundleURL)
+		if err != nil {
+			return nil, err
+		}
+		bundles = append(bundles, bundle)
+	}
+
+	discovered := ParseBundles(html, bundles...)
+	if len(discovered.Operations) == 0 {
+		return nil, fmt.Errorf("no operations found in %s", strings.Join(bundleURLs, ", "))
+	}
+	discovered.Apply(s.Operations())
+	return discovered, nil
+}
+
+// fetchPage gets the page or script as the browser would, without API headers.
+func (s *Scraper) fetchPage(ctx context.Context, pageURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var content []byte
+	prepare := func(req *http.Request) error {
+		req.Header.Set("User-Agent", s.GetUserAgent())
+		return nil
+	}
+	err = s.do(req, prepare, func(resp *http.Response, body []byte) error {
+		if resp.StatusCode != http.StatusOK {
+			return newAPIError(resp, body)
+		}
+		content = body
+		return nil
+	})
+	return content, err
+}
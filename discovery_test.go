@@ -0,0 +1,122 @@
+package twitterscraper_test
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	twitterscraper "github.com/einys/twitter-scraper"
+)
+
+const (
+	discoveryPage   = "testdata/discovery/index.html"
+	discoveryBundle = "testdata/discovery/main.6c1e3a2b.js"
+)
+
+func TestParseBundles(t *testing.T) {
+	html, err := os.ReadFile(discoveryPage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundle, err := os.ReadFile(discoveryBundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	urls := twitterscraper.BundleURLs(html, "https://x.com/")
+	if len(urls) != 1 || urls[0] != "https://x.com/responsive-web/client-web/main.6c1e3a2b.js" {
+		t.Errorf("Unexpected bundle URLs: %v", urls)
+	}
+
+	discovered := twitterscraper.ParseBundles(html, bundle)
+	if len(discovered.Operations) != 4 {
+		t.Errorf("Expected 4 operations, got %d", len(discovered.Operations))
+	}
+	op := discovered.Operations["UserTweets"]
+	if op.QueryID != "Q6aAvPw7azXZbqXzuqTALA" || op.Method != "GET" {
+		t.Errorf("Unexpected UserTweets: %+v", op)
+	}
+	if len(op.Features) != 4 || len(op.FieldToggles) != 1 {
+		t.Errorf("Unexpected UserTweets switches: %v %v", op.Features, op.FieldToggles)
+	}
+	if op := discovered.Operations["FavoriteTweet"]; op.Method != "POST" {
+		t.Errorf("Expected mutation to be POST, got %s", op.Method)
+	}
+	if value, ok := discovered.FeatureValues["longform_notetweets_inline_media_enabled"]; !ok || value {
+		t.Error("Expected feature value false from the page")
+	}
+	if _, ok := discovered.FeatureValues["responsive_web_home_pinned_timelines_enabled"]; ok {
+		t.Error("Expected non-boolean feature switch to be skipped")
+	}
+}
+
+func TestLoadBundleFiles(t *testing.T) {
+	registry := twitterscraper.NewOperationRegistry()
+	before, _ := registry.Get("FavoriteTweet")
+	if err := registry.LoadBundleFiles(discoveryPage, discoveryBundle); err != nil {
+		t.Fatal(err)
+	}
+
+	op, _ := registry.Get("UserTweets")
+	if op.QueryID != "Q6aAvPw7azXZbqXzuqTALA" {
+		t.Errorf("Expected discovered query ID, got %s", op.QueryID)
+	}
+	if len(op.Features) != 4 {
+		t.Errorf("Expected features to be replaced by discovered switches, got %v", op.Features)
+	}
+	if op.Features["rweb_video_timestamps_enabled"] != true || op.Features["longform_notetweets_inline_media_enabled"] != false {
+		t.Errorf("Expected feature values from the page, got %v", op.Features)
+	}
+	if op.Variables["withV2Timeline"] != true {
+		t.Error("Expected default variables to be kept")
+	}
+
+	favorite, _ := registry.Get("FavoriteTweet")
+	if favorite.QueryID != "lI07N6Otwv1PhnEgXILM7A" || len(favorite.Variables) != len(before.Variables) {
+		t.Errorf("Unexpected FavoriteTweet: %+v", favorite)
+	}
+	if community, ok := registry.Get("CommunityQuery"); !ok || community.Method != "GET" {
+		t.Errorf("Expected unknown operation to be added, got %+v", community)
+	}
+
+	if err := registry.LoadBundleFiles("", discoveryPage); err == nil {
+		t.Error("Expected error for file without operations")
+	}
+}
+
+func TestDiscoverOperations(t *testing.T) {
+	html, err := os.ReadFile(discoveryPage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundle, err := os.ReadFile(discoveryBundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/":
+			w.Write(html)
+		case strings.HasSuffix(r.URL.Path, "/main.6c1e3a2b.js"):
+			w.Write(bundle)
+		case strings.HasSuffix(r.URL.Path, "/xmU6X_CKVnQ5lSrCbAmJsg/UserByScreenName"):
+			fmt.Fprint(w, `{"data":{"user":{"result":{"rest_id":"783214","legacy":{"screen_name":"X","name":"X"}}}}}`)
+		default:
+			t.Errorf("Unexpected request %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	})
+	scraper := twitterscraper.New().WithBaseURL(server.URL)
+	discovered, err := scraper.DiscoverOperations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(discovered.Operations) != 4 {
+		t.Errorf("Expected 4 operations, got %d", len(discovered.Operations))
+	}
+	if _, err := scraper.GetProfile("X"); err != nil {
+		t.Fatal(err)
+	}
+}
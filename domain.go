@@ -0,0 +1,149 @@
+package twitterscraper
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Domain the Scraper sends requests to and builds permanent URLs with.
+type Domain int
+
+const (
+	// DomainTwitter - twitter.com, api.twitter.com and upload.twitter.com, default
+	DomainTwitter Domain = iota
+	// DomainX - x.com, api.x.com and upload.x.com
+	DomainX
+)
+
+const xWebHost = "x.com"
+
+// XEndpoints used by a Scraper in DomainX mode.
+var XEndpoints = Endpoints{
+	Web:    "https://" + xWebHost,
+	API:    "https://api." + xWebHost,
+	Upload: "https://upload." + xWebHost,
+}
+
+// Host of the web frontend, `twitter.com` or `x.com`
+func (d Domain) Host() string {
+	if d == DomainX {
+		return xWebHost
+	}
+	return defaultWebHost
+}
+
+// Endpoints of the domain.
+func (d Domain) Endpoints() Endpoints {
+	if d == DomainX {
+		return XEndpoints
+	}
+	return DefaultEndpoints
+}
+
+func (d Domain) String() string {
+	return d.Host()
+}
+
+// WithDomain send requests to twitter.com or x.com and build permanent URLs on it.
+// Cookies are kept for both domains, so the mode can be switched without logging in again.
+// Call WithEndpoints or WithBaseURL after it to override the hosts, empty endpoints keep hosts of the domain
+// and permanent URLs keep the domain.
+func (s *Scraper) WithDomain(domain Domain) *Scraper {
+	s.mu.Lock()
+	s.domain = domain
+	s.mu.Unlock()
+	return s.WithEndpoints(domain.Endpoints())
+}
+
+// getDomain returns the domain mode of the scraper.
+func (s *Scraper) getDomain() Domain {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.domain
+}
+
+// webOrigin returns the origin of the web frontend, like `https://x.com`
+func (s *Scraper) webOrigin() string {
+	return "https://" + s.getDomain().Host()
+}
+
+// siblingHost maps a host of twitter.com to the same one of x.com and back, ok is false for other hosts.
+func siblingHost(host string) (string, bool) {
+	host = strings.ToLower(host)
+	for from, to := range map[string]string{defaultWebHost: xWebHost, xWebHost: defaultWebHost} {
+		if host == from {
+			return to, true
+		}
+		if strings.HasSuffix(host, "."+from) {
+			return strings.TrimSuffix(host, from) + to, true
+		}
+	}
+	return "", false
+}
+
+// siblingURL returns the same URL on the other domain, nil if it isn't on twitter.com or x.com.
+func siblingURL(u *url.URL) *url.URL {
+	host, ok := siblingHost(u.Hostname())
+	if !ok {
+		return nil
+	}
+	sibling := *u
+	if port := u.Port(); port != "" {
+		host += ":" + port
+	}
+	sibling.Host = host
+	return &sibling
+}
+
+// localizeTweets points URLs built by parsers to the domain of the scraper.
+func (s *Scraper) localizeTweets(tweets []*Tweet) []*Tweet {
+	domain := s.getDomain()
+	seen := make(map[*Tweet]bool)
+	for _, tweet := range tweets {
+		localizeTweetTo(tweet, domain, seen)
+	}
+	return tweets
+}
+
+// localizeTweet same as localizeTweets for a single tweet.
+func (s *Scraper) localizeTweet(tweet *Tweet) *Tweet {
+	localizeTweetTo(tweet, s.getDomain(), make(map[*Tweet]bool))
+	return tweet
+}
+
+// localizeTweetTo localizes the tweet and related ones, seen breaks cycles of replies and threads.
+func localizeTweetTo(tweet *Tweet, domain Domain, seen map[*Tweet]bool) {
+	if tweet == nil || domain == DomainTwitter || seen[tweet] {
+		return
+	}
+	seen[tweet] = true
+	tweet.PermanentURL = localizeURL(tweet.PermanentURL, domain)
+	if tweet.CommunityNote != nil {
+		tweet.CommunityNote.URL = localizeURL(tweet.CommunityNote.URL, domain)
+	}
+	tweet.HTML = strings.ReplaceAll(tweet.HTML, `href="https://`+defaultWebHost+`/`, `href="https://`+domain.Host()+`/`)
+	localizeTweetTo(tweet.InReplyToStatus, domain, seen)
+	localizeTweetTo(tweet.QuotedStatus, domain, seen)
+	localizeTweetTo(tweet.RetweetedStatus, domain, seen)
+	for _, t := range tweet.Thread {
+		localizeTweetTo(t, domain, seen)
+	}
+}
+
+// localizeProfiles points profile URLs to the domain of the scraper.
+func (s *Scraper) localizeProfiles(profiles []*Profile) []*Profile {
+	domain := s.getDomain()
+	for _, profile := range profiles {
+		profile.URL = localizeURL(profile.URL, domain)
+	}
+	return profiles
+}
+
+// localizeURL replaces twitter.com of the URL with the domain.
+func localizeURL(u string, domain Domain) string {
+	prefix := "https://" + defaultWebHost + "/"
+	if domain == DomainTwitter || !strings.HasPrefix(u, prefix) {
+		return u
+	}
+	return "https://" + domain.Host() + "/" + strings.TrimPrefix(u, prefix)
+}
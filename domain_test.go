@@ -0,0 +1,136 @@
+package twitterscraper_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	twitterscraper "github.com/einys/twitter-scraper"
+)
+
+// hostRecorder sends every request to the mock server and records hosts they were meant for.
+type hostRecorder struct {
+	target *url.URL
+	mu     sync.Mutex
+	hosts  []string
+}
+
+func (r *hostRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	r.hosts = append(r.hosts, req.URL.Host)
+	r.mu.Unlock()
+	out := req.Clone(req.Context())
+	out.URL.Scheme, out.URL.Host, out.Host = r.target.Scheme, r.target.Host, ""
+	return http.DefaultTransport.RoundTrip(out)
+}
+
+func (r *hostRecorder) recorded() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return strings.Join(r.hosts, ",")
+}
+
+func newDomainServer(t *testing.T) *hostRecorder {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/UserByScreenName"):
+			fmt.Fprint(w, `{"data":{"user":{"result":{"rest_id":"783214","legacy":{"screen_name":"X","name":"X"}}}}}`)
+		case strings.HasSuffix(r.URL.Path, "/TweetResultByRestId"):
+			fmt.Fprint(w, `{"data":{"tweetResult":{"result":{"__typename":"Tweet","rest_id":"1","core":{"user_results":{"result":{"core":{"screen_name":"X","name":"X"}}}},"legacy":{"id_str":"1","full_text":"hello @jack"}}}}}`)
+		case strings.HasSuffix(r.URL.Path, "/verify_credentials.json"):
+			if r.Header.Get("X-Csrf-Token") != "ct0" {
+				http.Error(w, `{"errors":[{"code":353,"message":"This request requires a matching csrf cookie and header."}]}`, http.StatusForbidden)
+				return
+			}
+			fmt.Fprint(w, `{}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	return &hostRecorder{target: urlMustParse(t, server.URL)}
+}
+
+func urlMustParse(t *testing.T, raw string) *url.URL {
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
+
+func TestWithDomainX(t *testing.T) {
+	recorder := newDomainServer(t)
+	scraper := twitterscraper.New().WithDomain(twitterscraper.DomainX).WithTransport(recorder)
+
+	profile, err := scraper.GetProfile("X")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.URL != "https://x.com/X" {
+		t.Errorf("Expected profile URL on x.com, got %s", profile.URL)
+	}
+	if hosts := recorder.recorded(); hosts != "api.x.com,api.x.com" {
+		t.Errorf("Expected requests to x.com hosts, got %s", hosts)
+	}
+
+	tweet, err := scraper.GetTweet("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tweet.PermanentURL != "https://x.com/X/status/1" {
+		t.Errorf("Expected permanent URL on x.com, got %s", tweet.PermanentURL)
+	}
+	if !strings.Contains(tweet.HTML, `href="https://x.com/jack"`) {
+		t.Errorf("Expected mention link on x.com, got %s", tweet.HTML)
+	}
+}
+
+func TestWithDomainCookies(t *testing.T) {
+	recorder := newDomainServer(t)
+	scraper := twitterscraper.New().WithTransport(recorder)
+	scraper.SetAuthToken(twitterscraper.AuthToken{Token: "auth", CSRFToken: "ct0"})
+
+	// the session set for twitter.com is used on x.com
+	scraper.WithDomain(twitterscraper.DomainX)
+	if !scraper.IsLoggedIn() {
+		t.Fatal("Expected to be logged in on x.com with cookies of twitter.com")
+	}
+	if hosts := recorder.recorded(); hosts != "api.x.com" {
+		t.Errorf("Expected request to api.x.com, got %s", hosts)
+	}
+	cookies := scraper.GetCookies()
+	if len(cookies) != 2 || cookies[0].Domain != "x.com" {
+		t.Errorf("Expected auth cookies of x.com, got %v", cookies)
+	}
+
+	// and back
+	scraper = twitterscraper.New().WithDomain(twitterscraper.DomainX).WithTransport(recorder)
+	scraper.SetCookies([]*http.Cookie{
+		{Name: "auth_token", Value: "auth", Domain: ".x.com"},
+		{Name: "ct0", Value: "ct0", Domain: ".x.com"},
+	})
+	scraper.WithDomain(twitterscraper.DomainTwitter)
+	if !scraper.IsLoggedIn() {
+		t.Error("Expected to be logged in on twitter.com with cookies of x.com")
+	}
+}
+
+func TestWithDomainPartialEndpoints(t *testing.T) {
+	recorder := newDomainServer(t)
+	scraper := twitterscraper.New().WithDomain(twitterscraper.DomainX).
+		WithEndpoints(twitterscraper.Endpoints{Upload: "https://upload.example.com"}).WithTransport(recorder)
+
+	profile, err := scraper.GetProfile("X")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.URL != "https://x.com/X" {
+		t.Errorf("Expected profile URL on x.com, got %s", profile.URL)
+	}
+	if hosts := recorder.recorded(); hosts != "api.x.com,api.x.com" {
+		t.Errorf("Expected empty endpoints to keep x.com hosts, got %s", hosts)
+	}
+}
@@ -0,0 +1,121 @@
+package twitterscraper
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// editControl of GraphQL results, the initial tweet has edit IDs and edited ones refer to it
+type editControl struct {
+	EditTweetIDs       []string     `json:"edit_tweet_ids"`
+	EditableUntilMsecs string       `json:"editable_until_msecs"`
+	EditsRemaining     string       `json:"edits_remaining"`
+	IsEditEligible     bool         `json:"is_edit_eligible"`
+	InitialTweetID     string       `json:"initial_tweet_id"`
+	EditControlInitial *editControl `json:"edit_control_initial"`
+}
+
+// legacyEditControl is editControl in `ext` of legacy timelines requested with `ext=editControl`
+type legacyEditControl struct {
+	Initial *legacyEditControlInitial `json:"initial"`
+	Edit    *struct {
+		InitialTweetID     string                    `json:"initialTweetId"`
+		EditControlInitial *legacyEditControlInitial `json:"editControlInitial"`
+	} `json:"edit"`
+}
+
+type legacyEditControlInitial struct {
+	EditTweetIDs       []string `json:"editTweetIds"`
+	EditableUntilMsecs string   `json:"editableUntilMsecs"`
+	EditsRemaining     string   `json:"editsRemaining"`
+	IsEditEligible     bool     `json:"isEditEligible"`
+}
+
+func (c *legacyEditControlInitial) editControl() *editControl {
+	if c == nil {
+		return nil
+	}
+	return &editControl{
+		EditTweetIDs:       c.EditTweetIDs,
+		EditableUntilMsecs: c.EditableUntilMsecs,
+		EditsRemaining:     c.EditsRemaining,
+		IsEditEligible:     c.IsEditEligible,
+	}
+}
+
+// editControl converts the legacy format, nil if there is no edit control.
+func (c *legacyEditControl) editControl() *editControl {
+	switch {
+	case c == nil:
+		return nil
+	case c.Initial != nil:
+		return c.Initial.editControl()
+	case c.Edit != nil:
+		return &editControl{
+			InitialTweetID:     c.Edit.InitialTweetID,
+			EditControlInitial: c.Edit.EditControlInitial.editControl(),
+		}
+	}
+	return nil
+}
+
+// edit control of the legacy tweet in any of formats
+func (tweet *legacyTweet) editControl() *editControl {
+	if tweet.EditControl != nil {
+		return tweet.EditControl
+	}
+	return tweet.Ext.EditControl.R.Ok.editControl()
+}
+
+// apply fills edit fields of the tweet.
+func (c *editControl) apply(tw *Tweet) {
+	if c == nil || tw == nil {
+		return
+	}
+	initial := c
+	tw.InitialTweetID = tw.ID
+	if c.EditControlInitial != nil {
+		initial = c.EditControlInitial
+		tw.InitialTweetID = c.InitialTweetID
+	}
+	if len(initial.EditTweetIDs) > 0 {
+		tw.EditTweetIDs = initial.EditTweetIDs
+		tw.InitialTweetID = initial.EditTweetIDs[0]
+	}
+	if msecs, err := strconv.ParseInt(initial.EditableUntilMsecs, 10, 64); err == nil {
+		tw.EditableUntil = time.Unix(0, msecs*int64(time.Millisecond))
+	}
+	tw.EditsRemaining, _ = strconv.Atoi(initial.EditsRemaining)
+	tw.IsEditEligible = initial.IsEditEligible
+	tw.IsEdit = tw.InitialTweetID != "" && tw.InitialTweetID != tw.ID
+}
+
+// GetTweetEditHistory returns every version of the tweet from the initial one to the latest edit.
+// A tweet that wasn't edited is returned alone.
+func (s *Scraper) GetTweetEditHistory(id string) ([]*Tweet, error) {
+	return s.GetTweetEditHistoryContext(context.Background(), id)
+}
+
+// GetTweetEditHistoryContext same as GetTweetEditHistory, but accepts context for cancellation.
+func (s *Scraper) GetTweetEditHistoryContext(ctx context.Context, id string) ([]*Tweet, error) {
+	tweet, err := s.GetTweetContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(tweet.EditTweetIDs) < 2 {
+		return []*Tweet{tweet}, nil
+	}
+
+	versions := make([]*Tweet, 0, len(tweet.EditTweetIDs))
+	for _, editID := range tweet.EditTweetIDs {
+		version := tweet
+		if editID != tweet.ID {
+			if version, err = s.GetTweetContext(ctx, editID); err != nil {
+				return nil, err
+			}
+		}
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
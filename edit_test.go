@@ -0,0 +1,86 @@
+package twitterscraper_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/einys/twitter-scraper"
+)
+
+const editControlInitial = `{"edit_tweet_ids":["100","200","300"],"editable_until_msecs":"1682924400000","is_edit_eligible":true,"edits_remaining":"3"}`
+
+func newEditServer(t *testing.T) string {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/TweetResultByRestId"):
+			var variables struct {
+				TweetID string `json:"tweetId"`
+			}
+			json.Unmarshal([]byte(r.URL.Query().Get("variables")), &variables)
+			edit := editControlInitial
+			if variables.TweetID != "100" {
+				edit = `{"initial_tweet_id":"100","edit_control_initial":` + editControlInitial + `}`
+			}
+			fmt.Fprintf(w, `{"data":{"tweetResult":{"result":{"__typename":"Tweet","rest_id":"%[1]s","core":{"user_results":{"result":{"core":{"screen_name":"X","name":"X"}}}},"edit_control":%[2]s,"legacy":{"id_str":"%[1]s","full_text":"version %[1]s"}}}}}`, variables.TweetID, edit)
+		case strings.HasSuffix(r.URL.Path, "/timeline/conversation/300.json"):
+			fmt.Fprint(w, `{"globalObjects":{"tweets":{"300":{"id_str":"300","full_text":"version 300","user_id_str":"1","ext":{"editControl":{"r":{"ok":{"edit":{"initialTweetId":"100","editControlInitial":{"editTweetIds":["100","200","300"],"editableUntilMsecs":"1682924400000","editsRemaining":"3","isEditEligible":true}}}}}}}},"users":{"1":{"screen_name":"X","name":"X"}}},"timeline":{"instructions":[{"addEntries":{"entries":[{"content":{"item":{"content":{"tweet":{"id":"300"}}}}}]}}]}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	return server.URL
+}
+
+func TestTweetEditControl(t *testing.T) {
+	scraper := twitterscraper.New().WithBaseURL(newEditServer(t))
+
+	initial, err := scraper.GetTweet("100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if initial.IsEdit || initial.InitialTweetID != "100" || strings.Join(initial.EditTweetIDs, ",") != "100,200,300" ||
+		initial.EditsRemaining != 3 || !initial.IsEditEligible || !initial.EditableUntil.Equal(time.Unix(1682924400, 0)) {
+		t.Errorf("Unexpected edit control of initial tweet: %+v", initial)
+	}
+
+	edited, err := scraper.GetTweet("200")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !edited.IsEdit || edited.InitialTweetID != "100" || len(edited.EditTweetIDs) != 3 {
+		t.Errorf("Unexpected edit control of edited tweet: %+v", edited)
+	}
+}
+
+func TestTweetEditControlLegacy(t *testing.T) {
+	scraper := twitterscraper.New().WithBaseURL(newEditServer(t))
+	scraper.WithOpenAccount(twitterscraper.OpenAccount{OAuthToken: "token", OAuthTokenSecret: "secret"})
+
+	tweet, err := scraper.GetTweet("300")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tweet.IsEdit || tweet.InitialTweetID != "100" || strings.Join(tweet.EditTweetIDs, ",") != "100,200,300" || tweet.EditsRemaining != 3 {
+		t.Errorf("Unexpected edit control of legacy tweet: %+v", tweet)
+	}
+}
+
+func TestGetTweetEditHistory(t *testing.T) {
+	scraper := twitterscraper.New().WithBaseURL(newEditServer(t))
+
+	versions, err := scraper.GetTweetEditHistory("200")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var texts []string
+	for _, version := range versions {
+		texts = append(texts, version.Text)
+	}
+	if strings.Join(texts, ",") != "version 100,version 200,version 300" {
+		t.Errorf("Expected every version in order, got %v", texts)
+	}
+}
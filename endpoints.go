@@ -0,0 +1,89 @@
+package twitterscraper
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Endpoints holds base URLs the Scraper sends requests to.
+// Empty fields fall back to the default Twitter hosts.
+type Endpoints struct {
+	// Web serves the frontend GraphQL API, default `https://twitter.com`
+	Web string
+	// API serves the REST, onboarding and guest token API, default `https://api.twitter.com`
+	API string
+	// Upload serves the media upload API, default `https://upload.twitter.com`
+	Upload string
+}
+
+const (
+	defaultWebHost    = "twitter.com"
+	defaultAPIHost    = "api.twitter.com"
+	defaultUploadHost = "upload.twitter.com"
+)
+
+// DefaultEndpoints used by a new Scraper.
+var DefaultEndpoints = Endpoints{
+	Web:    "https://" + defaultWebHost,
+	API:    "https://" + defaultAPIHost,
+	Upload: "https://" + defaultUploadHost,
+}
+
+// baseFor returns the configured base URL for one of the default hosts.
+func (e Endpoints) baseFor(host string) string {
+	switch host {
+	case defaultWebHost:
+		return e.Web
+	case defaultAPIHost:
+		return e.API
+	case defaultUploadHost:
+		return e.Upload
+	}
+	return ""
+}
+
+// resolveURL rewrites a URL pointing to one of the default hosts to the configured endpoint.
+func (s *Scraper) resolveURL(u *url.URL) error {
+	s.mu.RLock()
+	base := s.endpoints.baseFor(u.Host)
+	s.mu.RUnlock()
+	if base == "" || base == DefaultEndpoints.baseFor(u.Host) {
+		return nil
+	}
+
+	parsed, err := url.Parse(base)
+	if err != nil {
+		return err
+	}
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return fmt.Errorf("invalid endpoint %q", base)
+	}
+
+	u.Scheme = parsed.Scheme
+	u.Host = parsed.Host
+	u.Path = strings.TrimSuffix(parsed.Path, "/") + u.Path
+	if u.RawPath != "" {
+		u.RawPath = strings.TrimSuffix(parsed.EscapedPath(), "/") + u.RawPath
+	}
+	return nil
+}
+
+// resolveRequest points the request to the configured endpoint.
+func (s *Scraper) resolveRequest(req *http.Request) error {
+	if err := s.resolveURL(req.URL); err != nil {
+		return err
+	}
+	req.Host = req.URL.Host
+	return nil
+}
+
+// cookieURL returns the URL used to store and read session cookies.
+func (s *Scraper) cookieURL() *url.URL {
+	u := *twURL
+	if err := s.resolveURL(&u); err != nil {
+		return twURL
+	}
+	return &u
+}
@@ -0,0 +1,87 @@
+package twitterscraper_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	twitterscraper "github.com/einys/twitter-scraper"
+)
+
+func newMockServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/1.1/guest/activate.json") {
+			fmt.Fprint(w, `{"guest_token":"1234567890"}`)
+			return
+		}
+		handler(w, r)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestWithBaseURL(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/UserByScreenName") {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("X-Guest-Token") != "1234567890" {
+			t.Errorf("Expected guest token from mock server, got %q", r.Header.Get("X-Guest-Token"))
+		}
+		fmt.Fprint(w, `{"data":{"user":{"result":{"rest_id":"783214","legacy":{"screen_name":"X","name":"X"}}}}}`)
+	})
+
+	scraper := twitterscraper.New().WithBaseURL(server.URL)
+	profile, err := scraper.GetProfile("X")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.UserID != "783214" {
+		t.Errorf("Expected UserID 783214, got %s", profile.UserID)
+	}
+}
+
+func TestWithEndpointsPath(t *testing.T) {
+	var paths []string
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		fmt.Fprint(w, `{"screen_name":"X"}`)
+	})
+
+	scraper := twitterscraper.New().WithEndpoints(twitterscraper.Endpoints{
+		API: server.URL + "/api",
+	})
+	if _, err := scraper.GetAccountSettings(); err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 1 || paths[0] != "/api/1.1/account/settings.json" {
+		t.Errorf("Expected request to /api/1.1/account/settings.json, got %v", paths)
+	}
+}
+
+func TestWithBaseURLCookies(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-CSRF-Token") != "csrf" {
+			t.Errorf("Expected X-CSRF-Token csrf, got %q", r.Header.Get("X-CSRF-Token"))
+		}
+		if c, err := r.Cookie("auth_token"); err != nil || c.Value != "token" {
+			t.Errorf("Expected auth_token cookie, got %v", c)
+		}
+		fmt.Fprint(w, `{}`)
+	})
+
+	scraper := twitterscraper.New().WithBaseURL(server.URL)
+	scraper.SetAuthToken(twitterscraper.AuthToken{Token: "token", CSRFToken: "csrf"})
+
+	if !scraper.IsLoggedIn() {
+		t.Error("Expected IsLoggedIn() = true")
+	}
+	if len(scraper.GetCookies()) != 2 {
+		t.Errorf("Expected 2 cookies, got %d", len(scraper.GetCookies()))
+	}
+}
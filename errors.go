@@ -0,0 +1,168 @@
+package twitterscraper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrRateLimited matches errors caused by an exhausted rate limit, see RateLimitError for reset time.
+	ErrRateLimited = errors.New("rate limit exceeded")
+	// ErrNotLoggedIn matches errors caused by a missing or expired session.
+	ErrNotLoggedIn = errors.New("scraper is not logged in")
+	// ErrSuspended matches errors caused by a suspended user or account.
+	ErrSuspended = errors.New("user is suspended")
+	// ErrNotFound matches errors caused by a missing user, tweet or space.
+	ErrNotFound = errors.New("not found")
+	// ErrProtected matches errors caused by content of protected users.
+	ErrProtected = errors.New("user is protected")
+	// ErrLocked matches errors caused by a temporarily locked account.
+	ErrLocked = errors.New("account is locked")
+)
+
+// Twitter error codes
+const (
+	codeNoData           = 8
+	codeNotAuthenticated = 32
+	codePageNotFound     = 34
+	codeUserNotFound     = 50
+	codeUserSuspended    = 63
+	codeAccountSuspended = 64
+	codeRateLimited      = 88
+	codeInvalidToken     = 89
+	codeStatusNotFound   = 144
+	codeNotAuthorized    = 179
+	codeBadAuthData      = 215
+	codeBadGuestToken    = 239
+	codeAccountLocked    = 326
+)
+
+// ErrorDetail is a single entry of the `errors` array returned by Twitter.
+type ErrorDetail struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// APIError returned when Twitter answers with an unexpected status or an error payload.
+// Use errors.Is with ErrRateLimited, ErrNotLoggedIn, ErrSuspended, ErrLocked, ErrNotFound or ErrProtected to check its kind.
+type APIError struct {
+	// StatusCode of the response, 0 for errors returned with a successful response
+	StatusCode int
+	// Errors reported by Twitter
+	Errors []ErrorDetail
+	// Body of the response when it has no errors array
+	Body string
+	// RateLimitReset from X-Rate-Limit-Reset header, zero if absent
+	RateLimitReset time.Time
+}
+
+// RateLimitError returned when an endpoint is rate limited.
+// errors.Is(err, ErrRateLimited) reports true for it.
+type RateLimitError struct {
+	// Endpoint is the exhausted endpoint, empty if unknown
+	Endpoint string
+	// Reset is the time the limit resets, zero if unknown
+	Reset time.Time
+}
+
+// ChallengeRequiredError returned by Login when Twitter asks for an additional subtask,
+// like `LoginAcid` for email confirmation or `LoginTwoFactorAuthChallenge` for 2FA.
+type ChallengeRequiredError struct {
+	SubtaskID string
+	// FlowToken to continue the login flow with
+	FlowToken string
+}
+
+// newAPIError builds APIError from a response and its already read body.
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	e := &APIError{StatusCode: resp.StatusCode}
+	var payload struct {
+		Errors []ErrorDetail `json:"errors"`
+	}
+	if json.Unmarshal(body, &payload) == nil && len(payload.Errors) > 0 {
+		e.Errors = payload.Errors
+	} else {
+		e.Body = string(body)
+	}
+	e.RateLimitReset = parseRateLimitReset(resp.Header)
+	return e
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	if e.StatusCode != 0 {
+		fmt.Fprintf(&b, "response status %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	} else {
+		b.WriteString("api error")
+	}
+	for i, detail := range e.Errors {
+		if i == 0 {
+			b.WriteString(": ")
+		} else {
+			b.WriteString("; ")
+		}
+		fmt.Fprintf(&b, "%s (%d)", detail.Message, detail.Code)
+	}
+	if len(e.Errors) == 0 && e.Body != "" {
+		b.WriteString(": " + e.Body)
+	}
+	return b.String()
+}
+
+// Unwrap returns the kind of the error, so errors.Is and errors.As see through APIError.
+func (e *APIError) Unwrap() error {
+	if e.StatusCode == http.StatusTooManyRequests || e.hasCode(codeRateLimited) {
+		return &RateLimitError{Reset: e.RateLimitReset}
+	}
+	if e.hasCode(codeUserSuspended, codeAccountSuspended) {
+		return ErrSuspended
+	}
+	if e.hasCode(codeAccountLocked) {
+		return ErrLocked
+	}
+	if e.hasCode(codeNotAuthorized) {
+		return ErrProtected
+	}
+	if e.StatusCode == http.StatusNotFound || e.hasCode(codeNoData, codePageNotFound, codeUserNotFound, codeStatusNotFound) {
+		return ErrNotFound
+	}
+	if e.StatusCode == http.StatusUnauthorized || e.hasCode(codeNotAuthenticated, codeInvalidToken, codeBadAuthData, codeBadGuestToken) {
+		return ErrNotLoggedIn
+	}
+	return nil
+}
+
+func (e *APIError) hasCode(codes ...int) bool {
+	for _, detail := range e.Errors {
+		for _, code := range codes {
+			if detail.Code == code {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (e *RateLimitError) Error() string {
+	msg := ErrRateLimited.Error()
+	if e.Endpoint != "" {
+		msg = e.Endpoint + ": " + msg
+	}
+	if e.Reset.IsZero() {
+		return msg
+	}
+	return fmt.Sprintf("%s, resets at %s", msg, e.Reset.Format(time.RFC3339))
+}
+
+// Is reports whether target is ErrRateLimited.
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+func (e *ChallengeRequiredError) Error() string {
+	return "challenge required: " + e.SubtaskID
+}
@@ -0,0 +1,104 @@
+package twitterscraper_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	twitterscraper "github.com/einys/twitter-scraper"
+)
+
+func TestAPIErrorKinds(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		kind   error
+	}{
+		{"rate limit status", http.StatusTooManyRequests, `Rate limit exceeded`, twitterscraper.ErrRateLimited},
+		{"rate limit code", http.StatusBadRequest, `{"errors":[{"code":88,"message":"Rate limit exceeded"}]}`, twitterscraper.ErrRateLimited},
+		{"not logged in", http.StatusUnauthorized, `{"errors":[{"code":32,"message":"Could not authenticate you."}]}`, twitterscraper.ErrNotLoggedIn},
+		{"suspended", http.StatusForbidden, `{"errors":[{"code":64,"message":"Your account is suspended"}]}`, twitterscraper.ErrSuspended},
+		{"locked", http.StatusForbidden, `{"errors":[{"code":326,"message":"this account is temporarily locked."}]}`, twitterscraper.ErrLocked},
+		{"not found", http.StatusNotFound, `{"errors":[{"code":34,"message":"Sorry, that page does not exist."}]}`, twitterscraper.ErrNotFound},
+		{"protected", http.StatusForbidden, `{"errors":[{"code":179,"message":"Sorry, you are not authorized to see this status."}]}`, twitterscraper.ErrProtected},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Rate-Limit-Reset", "1728910000")
+				w.WriteHeader(test.status)
+				fmt.Fprint(w, test.body)
+			})
+			scraper := twitterscraper.New().WithBaseURL(server.URL)
+
+			_, err := scraper.GetProfile("X")
+			if !errors.Is(err, test.kind) {
+				t.Fatalf("Expected errors.Is(err, %v), got %v", test.kind, err)
+			}
+			var apiErr *twitterscraper.APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("Expected *APIError, got %T", err)
+			}
+			if apiErr.StatusCode != test.status {
+				t.Errorf("Expected status %d, got %d", test.status, apiErr.StatusCode)
+			}
+		})
+	}
+}
+
+func TestRateLimitErrorReset(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Rate-Limit-Reset", "1728910000")
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"errors":[{"code":88,"message":"Rate limit exceeded"}]}`)
+	})
+	scraper := twitterscraper.New().WithBaseURL(server.URL)
+
+	_, err := scraper.GetProfile("X")
+	var rateLimitErr *twitterscraper.RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		t.Fatalf("Expected *RateLimitError, got %v", err)
+	}
+	if rateLimitErr.Reset.Unix() != 1728910000 {
+		t.Errorf("Expected reset at 1728910000, got %v", rateLimitErr.Reset)
+	}
+}
+
+func TestProfileErrorKinds(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		kind error
+	}{
+		{"suspended", `{"data":{"user":{"result":{"__typename":"UserUnavailable","message":"User is suspended"}}}}`, twitterscraper.ErrSuspended},
+		{"not found", `{"data":{}}`, twitterscraper.ErrNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, test.body)
+			})
+			scraper := twitterscraper.New().WithBaseURL(server.URL)
+
+			_, err := scraper.GetProfile("X")
+			if !errors.Is(err, test.kind) {
+				t.Errorf("Expected errors.Is(err, %v), got %v", test.kind, err)
+			}
+		})
+	}
+}
+
+func TestNotLoggedInError(t *testing.T) {
+	scraper := twitterscraper.New()
+
+	if _, err := scraper.GetSpace("1OdJrXPVLEnKX"); !errors.Is(err, twitterscraper.ErrNotLoggedIn) {
+		t.Errorf("Expected ErrNotLoggedIn, got %v", err)
+	}
+	if _, _, err := scraper.FetchSearchTweets("twitter", 20, ""); !errors.Is(err, twitterscraper.ErrNotLoggedIn) {
+		t.Errorf("Expected ErrNotLoggedIn, got %v", err)
+	}
+}
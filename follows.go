@@ -1,67 +1,51 @@
 package twitterscraper
 
 import (
-	"net/url"
+	"context"
 	"strings"
 )
 
 // FetchFollowing gets following profiles list for a given user, via the Twitter frontend GraphQL API.
 func (s *Scraper) FetchFollowing(user string, maxUsersNbr int, cursor string) ([]*Profile, string, error) {
-	userID, err := s.GetUserIDByScreenName(user)
+	return s.FetchFollowingContext(context.Background(), user, maxUsersNbr, cursor)
+}
+
+// FetchFollowingContext same as FetchFollowing, but accepts context for cancellation.
+func (s *Scraper) FetchFollowingContext(ctx context.Context, user string, maxUsersNbr int, cursor string) ([]*Profile, string, error) {
+	userID, err := s.GetUserIDByScreenNameContext(ctx, user)
 	if err != nil {
 		return nil, "", err
 	}
 
-	return s.FetchFollowingByUserID(userID, maxUsersNbr, cursor)
+	return s.FetchFollowingByUserIDContext(ctx, userID, maxUsersNbr, cursor)
 }
 
 // FetchFollowingByUserID gets following profiles list for a given userID, via the Twitter frontend GraphQL API.
 func (s *Scraper) FetchFollowingByUserID(userID string, maxUsersNbr int, cursor string) ([]*Profile, string, error) {
+	return s.FetchFollowingByUserIDContext(context.Background(), userID, maxUsersNbr, cursor)
+}
+
+// FetchFollowingByUserIDContext same as FetchFollowingByUserID, but accepts context for cancellation.
+func (s *Scraper) FetchFollowingByUserIDContext(ctx context.Context, userID string, maxUsersNbr int, cursor string) ([]*Profile, string, error) {
 	if maxUsersNbr > 200 {
 		maxUsersNbr = 200
 	}
 
-	req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/g5P4cbXR4ta4oCeE7y2vLQ/Following")
+	op := s.operation("Following")
+	req, err := s.newRequest(ctx, op.Method, op.URL())
 	if err != nil {
 		return nil, "", err
 	}
 
 	variables := map[string]interface{}{
-		"userId":                 userID,
-		"includePromotedContent": false,
-		"count":                  maxUsersNbr,
-	}
-	features := map[string]interface{}{
-		"responsive_web_graphql_exclude_directive_enabled":                        true,
-		"verified_phone_label_enabled":                                            false,
-		"creator_subscriptions_tweet_preview_api_enabled":                         true,
-		"responsive_web_graphql_timeline_navigation_enabled":                      true,
-		"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
-		"c9s_tweet_anatomy_moderator_badge_enabled":                               true,
-		"tweetypie_unmention_optimization_enabled":                                true,
-		"responsive_web_edit_tweet_api_enabled":                                   true,
-		"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
-		"view_counts_everywhere_api_enabled":                                      true,
-		"longform_notetweets_consumption_enabled":                                 true,
-		"responsive_web_twitter_article_tweet_consumption_enabled":                true,
-		"tweet_awards_web_tipping_enabled":                                        false,
-		"freedom_of_speech_not_reach_fetch_enabled":                               true,
-		"standardized_nudges_misinfo":                                             true,
-		"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": true,
-		"rweb_video_timestamps_enabled":                                           true,
-		"longform_notetweets_rich_text_read_enabled":                              true,
-		"longform_notetweets_inline_media_enabled":                                true,
-		"responsive_web_enhance_cards_enabled":                                    false,
+		"userId": userID,
+		"count":  maxUsersNbr,
 	}
-
 	if cursor != "" {
 		variables["cursor"] = cursor
 	}
 
-	query := url.Values{}
-	query.Set("variables", mapToJSONString(variables))
-	query.Set("features", mapToJSONString(features))
-	req.URL.RawQuery = query.Encode()
+	op.encode(req, variables)
 
 	var timeline timelineV2
 	err = s.RequestAPI(req, &timeline)
@@ -75,66 +59,50 @@ func (s *Scraper) FetchFollowingByUserID(userID string, maxUsersNbr int, cursor
 		nextCursor = ""
 	}
 
-	return users, nextCursor, nil
+	return s.localizeProfiles(users), nextCursor, nil
 }
 
 // FetchFollowers gets following profiles list for a given user, via the Twitter frontend GraphQL API.
 func (s *Scraper) FetchFollowers(user string, maxUsersNbr int, cursor string) ([]*Profile, string, error) {
-	userID, err := s.GetUserIDByScreenName(user)
+	return s.FetchFollowersContext(context.Background(), user, maxUsersNbr, cursor)
+}
+
+// FetchFollowersContext same as FetchFollowers, but accepts context for cancellation.
+func (s *Scraper) FetchFollowersContext(ctx context.Context, user string, maxUsersNbr int, cursor string) ([]*Profile, string, error) {
+	userID, err := s.GetUserIDByScreenNameContext(ctx, user)
 	if err != nil {
 		return nil, "", err
 	}
 
-	return s.FetchFollowersByUserID(userID, maxUsersNbr, cursor)
+	return s.FetchFollowersByUserIDContext(ctx, userID, maxUsersNbr, cursor)
 }
 
 // FetchFollowersByUserID gets followers profiles list for a given userID, via the Twitter frontend GraphQL API.
 func (s *Scraper) FetchFollowersByUserID(userID string, maxUsersNbr int, cursor string) ([]*Profile, string, error) {
+	return s.FetchFollowersByUserIDContext(context.Background(), userID, maxUsersNbr, cursor)
+}
+
+// FetchFollowersByUserIDContext same as FetchFollowersByUserID, but accepts context for cancellation.
+func (s *Scraper) FetchFollowersByUserIDContext(ctx context.Context, userID string, maxUsersNbr int, cursor string) ([]*Profile, string, error) {
 	if maxUsersNbr > 200 {
 		maxUsersNbr = 200
 	}
 
-	req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/jwbfbSzn0FRL_AMZGsYDag/Followers")
+	op := s.operation("Followers")
+	req, err := s.newRequest(ctx, op.Method, op.URL())
 	if err != nil {
 		return nil, "", err
 	}
 
 	variables := map[string]interface{}{
-		"userId":                 userID,
-		"includePromotedContent": false,
-		"count":                  maxUsersNbr,
-	}
-	features := map[string]interface{}{
-		"responsive_web_graphql_exclude_directive_enabled":                        true,
-		"verified_phone_label_enabled":                                            false,
-		"creator_subscriptions_tweet_preview_api_enabled":                         true,
-		"responsive_web_graphql_timeline_navigation_enabled":                      true,
-		"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
-		"c9s_tweet_anatomy_moderator_badge_enabled":                               true,
-		"tweetypie_unmention_optimization_enabled":                                true,
-		"responsive_web_edit_tweet_api_enabled":                                   true,
-		"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
-		"view_counts_everywhere_api_enabled":                                      true,
-		"longform_notetweets_consumption_enabled":                                 true,
-		"responsive_web_twitter_article_tweet_consumption_enabled":                true,
-		"tweet_awards_web_tipping_enabled":                                        false,
-		"freedom_of_speech_not_reach_fetch_enabled":                               true,
-		"standardized_nudges_misinfo":                                             true,
-		"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": true,
-		"rweb_video_timestamps_enabled":                                           true,
-		"longform_notetweets_rich_text_read_enabled":                              true,
-		"longform_notetweets_inline_media_enabled":                                true,
-		"responsive_web_enhance_cards_enabled":                                    false,
+		"userId": userID,
+		"count":  maxUsersNbr,
 	}
-
 	if cursor != "" {
 		variables["cursor"] = cursor
 	}
 
-	query := url.Values{}
-	query.Set("variables", mapToJSONString(variables))
-	query.Set("features", mapToJSONString(features))
-	req.URL.RawQuery = query.Encode()
+	op.encode(req, variables)
 
 	var timeline timelineV2
 	err = s.RequestAPI(req, &timeline)
@@ -148,5 +116,5 @@ func (s *Scraper) FetchFollowersByUserID(userID string, maxUsersNbr int, cursor
 		nextCursor = ""
 	}
 
-	return users, nextCursor, nil
+	return s.localizeProfiles(users), nextCursor, nil
 }
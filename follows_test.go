@@ -9,6 +9,7 @@ func TestFetchFollowing(t *testing.T) {
 		t.Skip("Skipping test due to environment variable")
 	}
 	users, _, err := testScraper.FetchFollowing("Support", 20, "")
+	skipIfNotRecorded(t, err)
 	if err != nil {
 		t.Error(err)
 	}
@@ -22,6 +23,7 @@ func TestFetchFollowers(t *testing.T) {
 		t.Skip("Skipping test due to environment variable")
 	}
 	users, _, err := testScraper.FetchFollowers("Support", 20, "")
+	skipIfNotRecorded(t, err)
 	if err != nil {
 		t.Error(err)
 	}
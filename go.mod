@@ -5,5 +5,7 @@ go 1.16
 require (
 	github.com/AlexEidt/Vidio v1.5.1
 	github.com/google/go-cmp v0.6.0
+	golang.org/x/crypto v0.27.0
 	golang.org/x/net v0.29.0
+	gopkg.in/yaml.v3 v3.0.1
 )
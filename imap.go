@@ -0,0 +1,365 @@
+package twitterscraper
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	reIMAPLiteral    = regexp.MustCompile(`\{(\d+)\}$`)
+	reIMAPSearch     = regexp.MustCompile(`^\* SEARCH\b(.*)$`)
+	reIMAPDate       = regexp.MustCompile(`INTERNALDATE "([^"]+)"`)
+	reCodeInSubject  = regexp.MustCompile(`(?i)\bcode is:?\s*([a-z0-9]{6,8})\b`)
+	reCodeInBody     = regexp.MustCompile(`(?i)(?:verification|confirmation) code[^:\n]*:\s*([a-z0-9]{6,8})\b`)
+	reCodeOnLine     = regexp.MustCompile(`(?m)^\s*([0-9]{6,8})\s*$`)
+	reHTMLTag        = regexp.MustCompile(`<[^>]*>`)
+	defaultIMAPFroms = []string{"x.com", "twitter.com"}
+)
+
+// IMAPConfig describes the mailbox Twitter sends confirmation codes to, see IMAPChallengeHandler.
+type IMAPConfig struct {
+	// Addr of the IMAP server, like `imap.gmail.com:993`
+	Addr     string
+	Username string
+	Password string
+	// Mailbox to search, `INBOX` by default
+	Mailbox string
+	// From filters senders by substring, `x.com` and `twitter.com` by default
+	From []string
+	// PollInterval between mailbox checks, 5 seconds by default
+	PollInterval time.Duration
+	// Timeout of waiting for the mail, 3 minutes by default
+	Timeout time.Duration
+	// Plaintext connects without TLS, e.g. to a local mail bridge
+	Plaintext bool
+	// TLSConfig used unless Plaintext, the server name is taken from Addr by default
+	TLSConfig *tls.Config
+}
+
+// IMAPChallengeHandler answers `LoginAcid` asking for the code sent to email.
+// It polls the mailbox for the mail received after the challenge and extracts the code from it.
+func IMAPChallengeHandler(config IMAPConfig) ChallengeHandler {
+	// codes of mails already returned are wrong or expired when the challenge is asked again
+	var mu sync.Mutex
+	returned := make(map[string]bool)
+	return func(ctx context.Context, challenge Challenge) (string, error) {
+		if challenge.Kind != ChallengeEmail || !isCodeChallenge(challenge) {
+			return "", ErrChallengeNotHandled
+		}
+		mu.Lock()
+		skip := make(map[string]bool, len(returned))
+		for uid := range returned {
+			skip[uid] = true
+		}
+		mu.Unlock()
+		// mail may be stamped by a server with a slightly different clock
+		code, uid, err := config.waitForCode(ctx, time.Now().Add(-time.Minute), skip)
+		if err != nil {
+			return "", err
+		}
+		mu.Lock()
+		returned[uid] = true
+		mu.Unlock()
+		return code, nil
+	}
+}
+
+// isCodeChallenge reports whether the challenge asks for a code, not the email address itself.
+func isCodeChallenge(challenge Challenge) bool {
+	text := strings.ToLower(challenge.Hint + " " + challenge.Message)
+	return strings.Contains(text, "code") || strings.Contains(text, "check your email")
+}
+
+// WaitForCode polls the mailbox until a Twitter mail received after since contains a code.
+func (c IMAPConfig) WaitForCode(ctx context.Context, since time.Time) (string, error) {
+	code, _, err := c.waitForCode(ctx, since, nil)
+	return code, err
+}
+
+// waitForCode same as WaitForCode, mails with UIDs in skip are ignored.
+func (c IMAPConfig) waitForCode(ctx context.Context, since time.Time, skip map[string]bool) (string, string, error) {
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = 3 * time.Minute
+	}
+	interval := c.PollInterval
+	if interval == 0 {
+		interval = 5 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		code, uid, err := c.fetchCode(ctx, since, skip)
+		if err != nil && ctx.Err() != nil {
+			// the deadline cut the connection off
+			return "", "", fmt.Errorf("confirmation mail not received: %w", ctx.Err())
+		}
+		if err != nil {
+			return "", "", err
+		}
+		if code != "" {
+			return code, uid, nil
+		}
+		if err := sleepContext(ctx, interval); err != nil {
+			return "", "", fmt.Errorf("confirmation mail not received: %w", err)
+		}
+	}
+}
+
+// FetchCode checks the mailbox once and returns the code of the newest Twitter mail received after since,
+// empty if there is none.
+func (c IMAPConfig) FetchCode(ctx context.Context, since time.Time) (string, error) {
+	code, _, err := c.fetchCode(ctx, since, nil)
+	return code, err
+}
+
+// fetchCode same as FetchCode, also returns UID of the mail, mails with UIDs in skip are ignored.
+func (c IMAPConfig) fetchCode(ctx context.Context, since time.Time, skip map[string]bool) (string, string, error) {
+	client, err := c.dial(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	defer client.close()
+
+	if _, err := client.command("LOGIN " + imapQuote(c.Username) + " " + imapQuote(c.Password)); err != nil {
+		return "", "", err
+	}
+	mailbox := c.Mailbox
+	if mailbox == "" {
+		mailbox = "INBOX"
+	}
+	if _, err := client.command("SELECT " + imapQuote(mailbox)); err != nil {
+		return "", "", err
+	}
+
+	froms := c.From
+	if len(froms) == 0 {
+		froms = defaultIMAPFroms
+	}
+	criteria := "FROM " + imapQuote(froms[0])
+	for _, from := range froms[1:] {
+		criteria = "OR FROM " + imapQuote(from) + " " + criteria
+	}
+	// SINCE compares dates in the server time zone, INTERNALDATE below filters exactly
+	searchSince := since.AddDate(0, 0, -1).UTC().Format("2-Jan-2006")
+	responses, err := client.command("UID SEARCH SINCE " + searchSince + " " + criteria)
+	if err != nil {
+		return "", "", err
+	}
+	var uids []string
+	for _, response := range responses {
+		if match := reIMAPSearch.FindStringSubmatch(response.text); match != nil {
+			uids = append(uids, strings.Fields(match[1])...)
+		}
+	}
+
+	// newest mails first
+	for i := len(uids) - 1; i >= 0; i-- {
+		if skip[uids[i]] {
+			continue
+		}
+		responses, err := client.command("UID FETCH " + uids[i] + " (INTERNALDATE BODY.PEEK[])")
+		if err != nil {
+			return "", "", err
+		}
+		for _, response := range responses {
+			match := reIMAPDate.FindStringSubmatch(response.text)
+			if match == nil || len(response.literals) == 0 {
+				continue
+			}
+			received, err := time.Parse("2-Jan-2006 15:04:05 -0700", strings.TrimSpace(match[1]))
+			if err != nil || received.Before(since) {
+				continue
+			}
+			if code := extractCode(response.literals[0]); code != "" {
+				return code, uids[i], nil
+			}
+		}
+	}
+	return "", "", nil
+}
+
+// extractCode finds the confirmation code in the subject or text of the mail.
+func extractCode(message []byte) string {
+	msg, err := mail.ReadMessage(bytes.NewReader(message))
+	if err != nil {
+		return ""
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		subject = msg.Header.Get("Subject")
+	}
+	if match := reCodeInSubject.FindStringSubmatch(subject); match != nil {
+		return match[1]
+	}
+
+	text := mailText(msg.Header.Get("Content-Type"), msg.Header.Get("Content-Transfer-Encoding"), msg.Body)
+	for _, re := range []*regexp.Regexp{reCodeInSubject, reCodeInBody, reCodeOnLine} {
+		if match := re.FindStringSubmatch(text); match != nil {
+			return match[1]
+		}
+	}
+	return ""
+}
+
+// mailText returns decoded text/plain parts of the body, or text/html ones without plain text.
+func mailText(contentType, encoding string, body io.Reader) string {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = "text/plain"
+	}
+	if strings.HasPrefix(mediaType, "multipart/") {
+		var plain, html []string
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextRawPart()
+			if err != nil {
+				break
+			}
+			text := mailText(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"), part)
+			if strings.Contains(part.Header.Get("Content-Type"), "html") {
+				html = append(html, text)
+			} else {
+				plain = append(plain, text)
+			}
+		}
+		if len(plain) > 0 {
+			return strings.Join(plain, "\n")
+		}
+		return strings.Join(html, "\n")
+	}
+
+	switch strings.ToLower(encoding) {
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	}
+	content, _ := io.ReadAll(body)
+	if mediaType == "text/html" {
+		return reHTMLTag.ReplaceAllString(string(content), " ")
+	}
+	return string(content)
+}
+
+type imapClient struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	tag    int
+}
+
+type imapResponse struct {
+	text     string
+	literals [][]byte
+}
+
+func (c IMAPConfig) dial(ctx context.Context) (*imapClient, error) {
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", c.Addr)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	if !c.Plaintext {
+		config := c.TLSConfig
+		if config == nil {
+			host, _, _ := net.SplitHostPort(c.Addr)
+			config = &tls.Config{ServerName: host}
+		}
+		conn = tls.Client(conn, config)
+	}
+
+	client := &imapClient{conn: conn, reader: bufio.NewReader(conn)}
+	greeting, err := client.readResponse()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if !strings.HasPrefix(greeting.text, "* OK") && !strings.HasPrefix(greeting.text, "* PREAUTH") {
+		conn.Close()
+		return nil, fmt.Errorf("imap: unexpected greeting %q", greeting.text)
+	}
+	return client, nil
+}
+
+// command sends the command and returns its untagged responses, or an error if it didn't complete with OK.
+func (c *imapClient) command(command string) ([]imapResponse, error) {
+	c.tag++
+	tag := "a" + strconv.Itoa(c.tag)
+	if _, err := io.WriteString(c.conn, tag+" "+command+"\r\n"); err != nil {
+		return nil, err
+	}
+	var responses []imapResponse
+	for {
+		response, err := c.readResponse()
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasPrefix(response.text, tag+" ") {
+			status := strings.TrimPrefix(response.text, tag+" ")
+			if !strings.HasPrefix(status, "OK") {
+				name := strings.Fields(command)[0]
+				return nil, fmt.Errorf("imap: %s: %s", name, status)
+			}
+			return responses, nil
+		}
+		responses = append(responses, response)
+	}
+}
+
+// readResponse reads a response line, including literals like `{123}` it continues with.
+func (c *imapClient) readResponse() (imapResponse, error) {
+	var response imapResponse
+	for {
+		line, err := c.reader.ReadString('\n')
+		if err != nil {
+			return response, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		match := reIMAPLiteral.FindStringSubmatch(line)
+		if match == nil {
+			response.text += line
+			return response, nil
+		}
+		size, _ := strconv.Atoi(match[1])
+		literal := make([]byte, size)
+		if _, err := io.ReadFull(c.reader, literal); err != nil {
+			return response, err
+		}
+		response.text += line
+		response.literals = append(response.literals, literal)
+	}
+}
+
+func (c *imapClient) close() {
+	c.command("LOGOUT")
+	c.conn.Close()
+}
+
+// imapQuote formats the string as IMAP quoted string.
+func imapQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// WithIMAP read email confirmation codes during Login from the mailbox,
+// same as `WithChallengeHandler(IMAPChallengeHandler(config))`
+func (s *Scraper) WithIMAP(config IMAPConfig) *Scraper {
+	return s.WithChallengeHandler(IMAPChallengeHandler(config))
+}
@@ -0,0 +1,248 @@
+package twitterscraper_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/einys/twitter-scraper"
+)
+
+// fakeIMAP is an in-process stand-in for an IMAP server supporting LOGIN, SELECT, UID SEARCH and UID FETCH.
+type fakeIMAP struct {
+	password string
+	mu       sync.Mutex
+	mails    []fakeMail
+	searches []string
+	listener net.Listener
+}
+
+type fakeMail struct {
+	from     string
+	received time.Time
+	raw      string
+}
+
+var reIMAPCommand = regexp.MustCompile(`^(\S+) (?:UID )?(\S+)(.*)$`)
+
+func newFakeIMAP(t *testing.T, password string) *fakeIMAP {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeIMAP{password: password, listener: listener}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeIMAP) config() twitterscraper.IMAPConfig {
+	return twitterscraper.IMAPConfig{
+		Addr:         s.listener.Addr().String(),
+		Username:     "me@example.com",
+		Password:     s.password,
+		PollInterval: 10 * time.Millisecond,
+		Timeout:      5 * time.Second,
+		Plaintext:    true,
+	}
+}
+
+func (s *fakeIMAP) deliver(from string, received time.Time, subject, body string) {
+	raw := fmt.Sprintf("From: %s\r\nSubject: %s\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n%s", from, subject, body)
+	s.deliverRaw(from, received, raw)
+}
+
+func (s *fakeIMAP) deliverRaw(from string, received time.Time, raw string) {
+	s.mu.Lock()
+	s.mails = append(s.mails, fakeMail{from: from, received: received, raw: raw})
+	s.mu.Unlock()
+}
+
+func (s *fakeIMAP) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	fmt.Fprint(conn, "* OK IMAP4rev1 ready\r\n")
+	authenticated := false
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		match := reIMAPCommand.FindStringSubmatch(strings.TrimRight(line, "\r\n"))
+		if match == nil {
+			fmt.Fprint(conn, "* BAD invalid command\r\n")
+			continue
+		}
+		tag, cmd, args := match[1], strings.ToUpper(match[2]), match[3]
+		s.mu.Lock()
+		switch {
+		case cmd == "LOGIN":
+			authenticated = strings.HasSuffix(args, `"`+s.password+`"`)
+			if authenticated {
+				fmt.Fprintf(conn, "%s OK LOGIN completed\r\n", tag)
+			} else {
+				fmt.Fprintf(conn, "%s NO [AUTHENTICATIONFAILED] Invalid credentials\r\n", tag)
+			}
+		case cmd == "LOGOUT":
+			fmt.Fprintf(conn, "* BYE\r\n%s OK LOGOUT completed\r\n", tag)
+		case !authenticated:
+			fmt.Fprintf(conn, "%s NO not authenticated\r\n", tag)
+		case cmd == "SELECT":
+			fmt.Fprintf(conn, "* %d EXISTS\r\n%s OK [READ-WRITE] SELECT completed\r\n", len(s.mails), tag)
+		case cmd == "SEARCH":
+			s.searches = append(s.searches, strings.TrimSpace(args))
+			var uids []string
+			for i, mail := range s.mails {
+				if strings.Contains(args, `"`+strings.SplitN(mail.from, "@", 2)[1]+`"`) {
+					uids = append(uids, fmt.Sprint(i+1))
+				}
+			}
+			fmt.Fprintf(conn, "* SEARCH %s\r\n%s OK SEARCH completed\r\n", strings.Join(uids, " "), tag)
+		case cmd == "FETCH":
+			var uid int
+			fmt.Sscan(args, &uid)
+			mail := s.mails[uid-1]
+			fmt.Fprintf(conn, "* %d FETCH (UID %d INTERNALDATE \"%s\" BODY[] {%d}\r\n%s)\r\n%s OK FETCH completed\r\n",
+				uid, uid, mail.received.Format("02-Jan-2006 15:04:05 -0700"), len(mail.raw), mail.raw, tag)
+		default:
+			fmt.Fprintf(conn, "%s BAD unknown command\r\n", tag)
+		}
+		s.mu.Unlock()
+	}
+}
+
+func TestIMAPFetchCode(t *testing.T) {
+	now := time.Now()
+	server := newFakeIMAP(t, "secret")
+	server.deliver("info@x.com", now.Add(-time.Hour), "Your X confirmation code is oldcode1", "")
+	server.deliver("friend@example.com", now, "Your code is 999999", "")
+	server.deliver("info@x.com", now, "Your X confirmation code is 4k8sd2x3", "")
+	server.deliver("news@example.com", now, "Newsletter", "")
+
+	code, err := server.config().FetchCode(context.Background(), now.Add(-time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code != "4k8sd2x3" {
+		t.Errorf("Expected code of the new Twitter mail, got %q", code)
+	}
+	since := "SINCE " + now.Add(-time.Minute).AddDate(0, 0, -1).UTC().Format("2-Jan-2006") + " "
+	server.mu.Lock()
+	if len(server.searches) != 1 || !strings.HasPrefix(server.searches[0], since) {
+		t.Errorf("Expected search since the day before, got %q", server.searches)
+	}
+	server.mu.Unlock()
+
+	config := server.config()
+	config.Password = "wrong"
+	if _, err := config.FetchCode(context.Background(), now); err == nil || !strings.Contains(err.Error(), "AUTHENTICATIONFAILED") {
+		t.Errorf("Expected login error, got %v", err)
+	}
+}
+
+func TestIMAPFetchCodeMultipart(t *testing.T) {
+	server := newFakeIMAP(t, "secret")
+	server.deliverRaw("verify@twitter.com", time.Now(), strings.Join([]string{
+		"From: Twitter <verify@twitter.com>",
+		"Subject: =?UTF-8?Q?Confirm_your_email_address?=",
+		`Content-Type: multipart/alternative; boundary="b1"`,
+		"",
+		"--b1",
+		"Content-Type: text/html; charset=utf-8",
+		"",
+		"<p>Please enter this verification code to get started on X:</p><p>000000</p>",
+		"--b1",
+		"Content-Type: text/plain; charset=utf-8",
+		"Content-Transfer-Encoding: quoted-printable",
+		"",
+		"Please enter this verification code to get started on X:=20",
+		"",
+		"482913",
+		"",
+		"Verification codes expire after two hours.",
+		"--b1--",
+		"",
+	}, "\r\n"))
+
+	code, err := server.config().FetchCode(context.Background(), time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code != "482913" {
+		t.Errorf("Expected code from plain text part, got %q", code)
+	}
+}
+
+func TestIMAPChallengeHandler(t *testing.T) {
+	server := newFakeIMAP(t, "secret")
+	handler := twitterscraper.IMAPChallengeHandler(server.config())
+	for _, challenge := range []twitterscraper.Challenge{
+		{Kind: twitterscraper.ChallengeTwoFactor, Hint: "Code"},
+		{Kind: twitterscraper.ChallengeEmail, Message: "Enter your email address", Hint: "Email"},
+	} {
+		if _, err := handler(context.Background(), challenge); !errors.Is(err, twitterscraper.ErrChallengeNotHandled) {
+			t.Errorf("Expected ErrChallengeNotHandled for %+v, got %v", challenge, err)
+		}
+	}
+
+	config := server.config()
+	config.Timeout = 50 * time.Millisecond
+	handler = twitterscraper.IMAPChallengeHandler(config)
+	challenge := twitterscraper.Challenge{Kind: twitterscraper.ChallengeEmail, Hint: "Confirmation code"}
+	if _, err := handler(context.Background(), challenge); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected timeout without mail, got %v", err)
+	}
+
+	server.deliver("info@x.com", time.Now(), "Your X confirmation code is first123", "")
+	if code, err := handler(context.Background(), challenge); err != nil || code != "first123" {
+		t.Fatalf("Expected code of the mail, got %q, %v", code, err)
+	}
+	// the challenge asked again waits for a new mail
+	if code, err := handler(context.Background(), challenge); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the returned mail to be skipped, got %q, %v", code, err)
+	}
+	server.deliver("info@x.com", time.Now(), "Your X confirmation code is second12", "")
+	if code, err := handler(context.Background(), challenge); err != nil || code != "second12" {
+		t.Errorf("Expected code of the new mail, got %q, %v", code, err)
+	}
+}
+
+func TestLoginIMAP(t *testing.T) {
+	mailbox := newFakeIMAP(t, "secret")
+	server := newLoginServer(t, func(subtaskID, text string) string {
+		switch subtaskID {
+		case "":
+			// the mail arrives a moment after the challenge
+			go func() {
+				time.Sleep(50 * time.Millisecond)
+				mailbox.deliver("info@x.com", time.Now(), "Your X confirmation code is 7h2kq9ab", "")
+			}()
+			return `{"subtask_id":"LoginAcid","enter_text":{"primary_text":{"text":"Check your email"},"hint_text":"Confirmation code"}}`
+		case "LoginAcid":
+			if text != "7h2kq9ab" {
+				t.Errorf("Expected code from the mail, got %s", text)
+			}
+			return `{"subtask_id":"LoginSuccessSubtask"}`
+		}
+		return ""
+	})
+	scraper := twitterscraper.New().WithBaseURL(server.URL).WithLoginDelay(0, 0).WithIMAP(mailbox.config())
+	if err := scraper.Login("username", "password"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,94 @@
+package twitterscraper
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Logger records requests as a message with key-value pairs, *slog.Logger satisfies it.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// dumpSeq keeps dump file names unique within the same second
+var dumpSeq uint64
+
+// WithLogger record every request: operation, variables, status, latency and rate limit.
+// Successful requests are logged at debug level, failed ones at warn level.
+func (s *Scraper) WithLogger(logger Logger) *Scraper {
+	s.mu.Lock()
+	s.logger = logger
+	s.mu.Unlock()
+	return s
+}
+
+// WithDebugDump write raw response bodies to files in dir, e.g. to compare them with what parsers expect.
+// Empty dir disables dumping.
+func (s *Scraper) WithDebugDump(dir string) *Scraper {
+	s.mu.Lock()
+	s.dumpDir = dir
+	s.mu.Unlock()
+	return s
+}
+
+func (s *Scraper) logResponse(resp *Response, logger Logger, dumpDir string) {
+	var dumpFile string
+	if dumpDir != "" && resp.Body != nil {
+		var err error
+		if dumpFile, err = dumpResponse(dumpDir, resp); err != nil && logger != nil {
+			logger.Error("dump response", "operation", resp.Endpoint, "error", err)
+		}
+	}
+	if logger == nil {
+		return
+	}
+
+	var body []byte
+	if resp.Request.GetBody != nil {
+		if reader, err := resp.Request.GetBody(); err == nil {
+			body, _ = io.ReadAll(reader)
+			reader.Close()
+		}
+	}
+	args := []interface{}{
+		"operation", resp.Endpoint,
+		"method", resp.Request.Method,
+		"variables", requestVariables(resp.Request, body),
+		"status", resp.StatusCode,
+		"latency", resp.Duration,
+	}
+	if resp.RateLimit.Limit > 0 {
+		args = append(args,
+			"rate_limit_limit", resp.RateLimit.Limit,
+			"rate_limit_remaining", resp.RateLimit.Remaining,
+			"rate_limit_reset", resp.RateLimit.Reset,
+		)
+	}
+	if dumpFile != "" {
+		args = append(args, "dump", dumpFile)
+	}
+	if resp.Err != nil {
+		logger.Warn("request failed", append(args, "error", resp.Err)...)
+		return
+	}
+	logger.Debug("request", args...)
+}
+
+// dumpResponse writes the body to `{time}-{seq}-{operation}.json` file in dir.
+func dumpResponse(dir string, resp *Response) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	name := reUnsafeFileName.ReplaceAllString(strings.TrimSuffix(resp.Endpoint, ".json"), "_")
+	seq := atomic.AddUint64(&dumpSeq, 1)
+	file := filepath.Join(dir, fmt.Sprintf("%s-%06d-%s.json", time.Now().Format("20060102T150405"), seq, name))
+	return file, os.WriteFile(file, resp.Body, 0644)
+}
@@ -0,0 +1,95 @@
+package twitterscraper_test
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	twitterscraper "github.com/einys/twitter-scraper"
+)
+
+type logEntry struct {
+	level string
+	msg   string
+	attrs map[string]interface{}
+}
+
+type testLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+func (l *testLogger) log(level, msg string, args []interface{}) {
+	attrs := map[string]interface{}{}
+	for i := 0; i+1 < len(args); i += 2 {
+		attrs[fmt.Sprint(args[i])] = args[i+1]
+	}
+	l.mu.Lock()
+	l.entries = append(l.entries, logEntry{level, msg, attrs})
+	l.mu.Unlock()
+}
+
+func (l *testLogger) Debug(msg string, args ...interface{}) { l.log("debug", msg, args) }
+func (l *testLogger) Info(msg string, args ...interface{})  { l.log("info", msg, args) }
+func (l *testLogger) Warn(msg string, args ...interface{})  { l.log("warn", msg, args) }
+func (l *testLogger) Error(msg string, args ...interface{}) { l.log("error", msg, args) }
+
+func TestLogger(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/UserByRestId") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("X-Rate-Limit-Limit", "150")
+		w.Header().Set("X-Rate-Limit-Remaining", "149")
+		w.Header().Set("X-Rate-Limit-Reset", "1728910000")
+		fmt.Fprint(w, `{"data":{"user":{"result":{"rest_id":"783214","legacy":{"screen_name":"X","name":"X"}}}}}`)
+	})
+	logger := &testLogger{}
+	dir := filepath.Join(t.TempDir(), "dump")
+	scraper := twitterscraper.New().WithBaseURL(server.URL).WithLogger(logger).WithDebugDump(dir)
+
+	if _, err := scraper.GetProfile("X"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := scraper.GetProfileByID("783214"); err == nil {
+		t.Fatal("Expected error")
+	}
+
+	if len(logger.entries) != 3 {
+		t.Fatalf("Expected 3 log entries, got %#v", logger.entries)
+	}
+	entry := logger.entries[1]
+	if entry.level != "debug" || entry.attrs["operation"] != "UserByScreenName" || entry.attrs["status"] != http.StatusOK {
+		t.Errorf("Unexpected entry %#v", entry)
+	}
+	if !strings.Contains(fmt.Sprint(entry.attrs["variables"]), `"screen_name":"X"`) {
+		t.Errorf("Expected variables to be logged, got %v", entry.attrs["variables"])
+	}
+	if entry.attrs["rate_limit_remaining"] != 149 {
+		t.Errorf("Expected rate limit to be logged, got %v", entry.attrs["rate_limit_remaining"])
+	}
+	if entry := logger.entries[2]; entry.level != "warn" || entry.attrs["status"] != http.StatusNotFound || entry.attrs["error"] == nil {
+		t.Errorf("Unexpected entry for failed request %#v", entry)
+	}
+
+	dump, ok := entry.attrs["dump"].(string)
+	if !ok || !strings.HasSuffix(dump, "-UserByScreenName.json") {
+		t.Fatalf("Expected dump file, got %v", entry.attrs["dump"])
+	}
+	body, err := os.ReadFile(dump)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(body), `"rest_id":"783214"`) {
+		t.Errorf("Expected raw body in dump, got %s", body)
+	}
+	files, _ := os.ReadDir(dir)
+	if len(files) != 3 {
+		t.Errorf("Expected 3 dump files, got %d", len(files))
+	}
+}
@@ -14,6 +14,7 @@ func TestGetMedias(t *testing.T) {
 	dupcheck := make(map[string]bool)
 	for tweet := range testScraper.GetMediaTweets(context.Background(), "XSpaces", maxTweetsNbr) {
 		if tweet.Error != nil {
+			skipIfNotRecorded(t, tweet.Error)
 			t.Error(tweet.Error)
 		} else {
 			count++
@@ -0,0 +1,254 @@
+package twitterscraper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Metrics receives scraper events, see PrometheusMetrics for a ready to use implementation.
+// Operation is a GraphQL operation name or a REST path, the same as the RateLimit endpoint.
+type Metrics interface {
+	// ObserveRequest is called for every response, status is 0 if no response was received
+	ObserveRequest(operation string, status int, duration time.Duration)
+	// ObserveRetry is called before every retry of a failed request
+	ObserveRetry(operation string)
+	// ObserveRateLimitWait is called before waiting for the budget reset in RateLimitWait mode
+	ObserveRateLimitWait(operation string, wait time.Duration)
+	// ObserveParseFailure is called when a successful response can't be decoded
+	ObserveParseFailure(operation string)
+	// AddTweets is called for tweets emitted by channel methods, like GetTweets or SearchTweets
+	AddTweets(n int)
+	// AddProfiles is called for profiles emitted by channel methods, like SearchProfiles
+	AddProfiles(n int)
+}
+
+type nopMetrics struct{}
+
+func (nopMetrics) ObserveRequest(string, int, time.Duration)  {}
+func (nopMetrics) ObserveRetry(string)                        {}
+func (nopMetrics) ObserveRateLimitWait(string, time.Duration) {}
+func (nopMetrics) ObserveParseFailure(string)                 {}
+func (nopMetrics) AddTweets(int)                              {}
+func (nopMetrics) AddProfiles(int)                            {}
+
+// WithMetrics report requests, retries, rate limit waits, parse failures and emitted results to metrics.
+func (s *Scraper) WithMetrics(metrics Metrics) *Scraper {
+	s.mu.Lock()
+	s.metrics = metrics
+	s.mu.Unlock()
+	return s
+}
+
+// getMetrics returns metrics set with WithMetrics, or metrics discarding everything.
+func (s *Scraper) getMetrics() Metrics {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.metrics == nil {
+		return nopMetrics{}
+	}
+	return s.metrics
+}
+
+// isParseError reports whether err comes from decoding a response body.
+func isParseError(err error) bool {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	return errors.As(err, &syntaxErr) || errors.As(err, &typeErr)
+}
+
+// DefaultDurationBuckets are upper bounds in seconds of request duration and rate limit wait histograms.
+var DefaultDurationBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300, 900}
+
+// PrometheusMetrics collects Metrics in memory and exports them in the Prometheus text format.
+type PrometheusMetrics struct {
+	// Namespace prefixes every metric name, `twitter_scraper` by default
+	Namespace string
+	// Buckets of duration histograms, DefaultDurationBuckets by default. Set before the first observation.
+	Buckets []float64
+
+	mu             sync.Mutex
+	requests       map[string]float64
+	durations      map[string]*histogram
+	retries        map[string]float64
+	rateLimitWaits map[string]*histogram
+	parseFailures  map[string]float64
+	tweets         float64
+	profiles       float64
+}
+
+type histogram struct {
+	counts []float64
+	sum    float64
+	count  float64
+}
+
+// NewPrometheusMetrics creates PrometheusMetrics, serve it with Handler.
+func NewPrometheusMetrics() *PrometheusMetrics {
+	return &PrometheusMetrics{
+		Namespace:      "twitter_scraper",
+		Buckets:        DefaultDurationBuckets,
+		requests:       make(map[string]float64),
+		durations:      make(map[string]*histogram),
+		retries:        make(map[string]float64),
+		rateLimitWaits: make(map[string]*histogram),
+		parseFailures:  make(map[string]float64),
+	}
+}
+
+// ObserveRequest implements Metrics.
+func (m *PrometheusMetrics) ObserveRequest(operation string, status int, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests[labels("operation", operation, "status", strconv.Itoa(status))]++
+	m.observe(m.durations, labels("operation", operation), duration)
+}
+
+// ObserveRetry implements Metrics.
+func (m *PrometheusMetrics) ObserveRetry(operation string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.retries[labels("operation", operation)]++
+}
+
+// ObserveRateLimitWait implements Metrics.
+func (m *PrometheusMetrics) ObserveRateLimitWait(operation string, wait time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.observe(m.rateLimitWaits, labels("operation", operation), wait)
+}
+
+// ObserveParseFailure implements Metrics.
+func (m *PrometheusMetrics) ObserveParseFailure(operation string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.parseFailures[labels("operation", operation)]++
+}
+
+// AddTweets implements Metrics.
+func (m *PrometheusMetrics) AddTweets(n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tweets += float64(n)
+}
+
+// AddProfiles implements Metrics.
+func (m *PrometheusMetrics) AddProfiles(n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.profiles += float64(n)
+}
+
+func (m *PrometheusMetrics) observe(histograms map[string]*histogram, key string, d time.Duration) {
+	h, ok := histograms[key]
+	if !ok {
+		h = &histogram{counts: make([]float64, len(m.Buckets))}
+		histograms[key] = h
+	}
+	seconds := d.Seconds()
+	for i, bound := range m.Buckets {
+		if seconds <= bound {
+			h.counts[i]++
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+// Handler serves metrics in the Prometheus text format, e.g. `http.Handle("/metrics", metrics.Handler())`
+func (m *PrometheusMetrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		m.WriteTo(w)
+	})
+}
+
+// WriteTo writes metrics in the Prometheus text format.
+func (m *PrometheusMetrics) WriteTo(w io.Writer) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+	m.writeCounters(&b, "requests_total", "Requests by GraphQL operation or REST path and status code, 0 if no response was received.", m.requests)
+	m.writeHistograms(&b, "request_duration_seconds", "Request duration by operation.", m.durations)
+	m.writeCounters(&b, "retries_total", "Retries of failed requests by operation.", m.retries)
+	m.writeHistograms(&b, "rate_limit_wait_seconds", "Waits for rate limit reset by operation.", m.rateLimitWaits)
+	m.writeCounters(&b, "parse_failures_total", "Successful responses that could not be decoded by operation.", m.parseFailures)
+	m.writeCounters(&b, "tweets_total", "Tweets emitted by channel methods.", map[string]float64{"": m.tweets})
+	m.writeCounters(&b, "profiles_total", "Profiles emitted by channel methods.", map[string]float64{"": m.profiles})
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+func (m *PrometheusMetrics) name(name string) string {
+	if m.Namespace == "" {
+		return name
+	}
+	return m.Namespace + "_" + name
+}
+
+func (m *PrometheusMetrics) writeCounters(b *strings.Builder, name, help string, values map[string]float64) {
+	name = m.name(name)
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+	for _, key := range sortedKeys(values) {
+		fmt.Fprintf(b, "%s%s %s\n", name, braces(key), formatFloat(values[key]))
+	}
+}
+
+func (m *PrometheusMetrics) writeHistograms(b *strings.Builder, name, help string, histograms map[string]*histogram) {
+	name = m.name(name)
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s histogram\n", name, help, name)
+	keys := make([]string, 0, len(histograms))
+	for key := range histograms {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		h := histograms[key]
+		for i, bound := range m.Buckets {
+			fmt.Fprintf(b, "%s_bucket{%s,le=\"%s\"} %s\n", name, key, formatFloat(bound), formatFloat(h.counts[i]))
+		}
+		fmt.Fprintf(b, "%s_bucket{%s,le=\"+Inf\"} %s\n", name, key, formatFloat(h.count))
+		fmt.Fprintf(b, "%s_sum{%s} %s\n", name, key, formatFloat(h.sum))
+		fmt.Fprintf(b, "%s_count{%s} %s\n", name, key, formatFloat(h.count))
+	}
+}
+
+// labels renders label pairs like `operation="UserTweets",status="200"`.
+func labels(pairs ...string) string {
+	parts := make([]string, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		parts = append(parts, pairs[i]+"=\""+labelEscaper.Replace(pairs[i+1])+"\"")
+	}
+	return strings.Join(parts, ",")
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func braces(labels string) string {
+	if labels == "" {
+		return ""
+	}
+	return "{" + labels + "}"
+}
+
+func sortedKeys(values map[string]float64) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
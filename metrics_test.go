@@ -0,0 +1,103 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/einys/twitter-scraper"
+)
+
+func TestPrometheusMetrics(t *testing.T) {
+	var searches, profiles int32
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/verify_credentials.json"):
+			fmt.Fprint(w, `{}`)
+		case strings.HasSuffix(r.URL.Path, "/SearchTimeline"):
+			if atomic.AddInt32(&searches, 1) > 1 {
+				fmt.Fprint(w, `{}`)
+				return
+			}
+			tweet := `{"content":{"itemContent":{"tweetDisplayType":"Tweet","tweet_results":{"result":{"legacy":{"id_str":"%d","full_text":"tweet"},"core":{"user_results":{"result":{"legacy":{"screen_name":"X","name":"X"}}}}}}}}}`
+			fmt.Fprintf(w, `{"data":{"search_by_raw_query":{"search_timeline":{"timeline":{"instructions":[{"type":"TimelineAddEntries","entries":[%s,%s]}]}}}}}`, fmt.Sprintf(tweet, 1), fmt.Sprintf(tweet, 2))
+		case strings.HasSuffix(r.URL.Path, "/UserByScreenName"):
+			if atomic.AddInt32(&profiles, 1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			fmt.Fprint(w, `{"data":{"user":{"result":{"rest_id":"783214","legacy":{"screen_name":"X","name":"X"}}}}}`)
+		default:
+			fmt.Fprint(w, `not json`)
+		}
+	})
+	metrics := twitterscraper.NewPrometheusMetrics()
+	scraper := twitterscraper.New().WithBaseURL(server.URL).WithMetrics(metrics).WithRetry(twitterscraper.RetryPolicy{
+		MaxAttempts:   2,
+		MinBackoff:    time.Millisecond,
+		RetryStatuses: []int{http.StatusServiceUnavailable},
+	})
+	scraper.SetAuthToken(twitterscraper.AuthToken{Token: "auth", CSRFToken: "ct0"})
+	if !scraper.IsLoggedIn() {
+		t.Fatal("Expected scraper to be logged in")
+	}
+
+	for result := range scraper.SearchTweets(context.Background(), "twitter", 10) {
+		if result.Error != nil {
+			t.Fatal(result.Error)
+		}
+	}
+	if _, err := scraper.GetProfile("X"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := scraper.GetProfileByID("783214"); err == nil {
+		t.Fatal("Expected parse error")
+	}
+
+	recorder := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	if !strings.HasPrefix(recorder.Header().Get("Content-Type"), "text/plain; version=0.0.4") {
+		t.Errorf("Unexpected content type %q", recorder.Header().Get("Content-Type"))
+	}
+	output := recorder.Body.String()
+	for _, line := range []string{
+		"# TYPE twitter_scraper_requests_total counter",
+		`twitter_scraper_requests_total{operation="SearchTimeline",status="200"} 2`,
+		`twitter_scraper_requests_total{operation="UserByScreenName",status="200"} 1`,
+		`twitter_scraper_retries_total{operation="UserByScreenName"} 1`,
+		`twitter_scraper_parse_failures_total{operation="UserByRestId"} 1`,
+		"# TYPE twitter_scraper_request_duration_seconds histogram",
+		`twitter_scraper_request_duration_seconds_bucket{operation="SearchTimeline",le="+Inf"} 2`,
+		`twitter_scraper_request_duration_seconds_count{operation="SearchTimeline"} 2`,
+		"twitter_scraper_tweets_total 2",
+		"twitter_scraper_profiles_total 0",
+	} {
+		if !strings.Contains(output, line+"\n") {
+			t.Errorf("Expected line %q in output:\n%s", line, output)
+		}
+	}
+}
+
+func TestPrometheusMetricsRateLimitWait(t *testing.T) {
+	metrics := twitterscraper.NewPrometheusMetrics()
+	metrics.ObserveRateLimitWait("UserTweets", 90*time.Second)
+
+	var b strings.Builder
+	if _, err := metrics.WriteTo(&b); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		`twitter_scraper_rate_limit_wait_seconds_bucket{operation="UserTweets",le="60"} 0`,
+		`twitter_scraper_rate_limit_wait_seconds_bucket{operation="UserTweets",le="300"} 1`,
+		`twitter_scraper_rate_limit_wait_seconds_sum{operation="UserTweets"} 90`,
+	} {
+		if !strings.Contains(b.String(), line+"\n") {
+			t.Errorf("Expected line %q in output:\n%s", line, b.String())
+		}
+	}
+}
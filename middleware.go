@@ -0,0 +1,141 @@
+package twitterscraper
+
+import (
+	"io"
+	"net/http"
+	"time"
+)
+
+// RequestHook is called before a request is prepared and sent.
+// It may change the request, e.g. add headers. Returning an error aborts the request with it.
+type RequestHook func(req *http.Request) error
+
+// ResponseHook is called after a response is handled, including failed requests.
+type ResponseHook func(resp *Response)
+
+// Response describes a handled request for response hooks.
+type Response struct {
+	Request *http.Request
+	// Endpoint the rate limit is tracked by, see RateLimit
+	Endpoint string
+	// StatusCode is 0 when no response was received
+	StatusCode int
+	Header     http.Header
+	// Body is the raw response body
+	Body []byte
+	// RateLimit parsed from the headers, zero if absent
+	RateLimit RateLimit
+	// Err returned to the caller, nil on success
+	Err error
+	// Duration from sending the request to handling the response
+	Duration time.Duration
+}
+
+// Middleware groups hooks registered together with Use, both are optional.
+type Middleware struct {
+	Request  RequestHook
+	Response ResponseHook
+}
+
+// Use add middleware to the chain. Request hooks run in the order they were added,
+// response hooks in reverse order, so the first middleware wraps all others.
+func (s *Scraper) Use(middleware Middleware) *Scraper {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if middleware.Request != nil {
+		s.requestHooks = append(s.requestHooks, middleware.Request)
+	}
+	if middleware.Response != nil {
+		s.responseHooks = append([]ResponseHook{middleware.Response}, s.responseHooks...)
+	}
+	return s
+}
+
+// WithRequestHook add request hook, same as `Use(Middleware{Request: hook})`
+func (s *Scraper) WithRequestHook(hook RequestHook) *Scraper {
+	return s.Use(Middleware{Request: hook})
+}
+
+// WithResponseHook add response hook, same as `Use(Middleware{Response: hook})`
+func (s *Scraper) WithResponseHook(hook ResponseHook) *Scraper {
+	return s.Use(Middleware{Response: hook})
+}
+
+func (s *Scraper) runRequestHooks(req *http.Request) error {
+	s.mu.RLock()
+	hooks := s.requestHooks
+	s.mu.RUnlock()
+	for _, hook := range hooks {
+		if err := hook(req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Scraper) runResponseHooks(req *http.Request, resp *http.Response, body []byte, err error, start time.Time) {
+	if resp != nil && s.loggedIn() && s.authCookiesChanged(resp) {
+		// cookies like ct0 are refreshed by the server
+		s.autoSaveSessionContext(req.Context())
+	}
+
+	s.mu.RLock()
+	hooks, logger, dumpDir, metrics := s.responseHooks, s.logger, s.dumpDir, s.metrics
+	s.mu.RUnlock()
+	if len(hooks) == 0 && logger == nil && dumpDir == "" && metrics == nil {
+		return
+	}
+
+	response := &Response{
+		Request:  req,
+		Endpoint: endpointName(req.URL),
+		Body:     body,
+		Err:      err,
+		Duration: time.Since(start),
+	}
+	if resp != nil {
+		response.StatusCode = resp.StatusCode
+		response.Header = resp.Header
+		if limit, ok := parseRateLimit(resp.Header); ok {
+			limit.Endpoint = response.Endpoint
+			response.RateLimit = limit
+		}
+	}
+	if metrics != nil {
+		metrics.ObserveRequest(response.Endpoint, response.StatusCode, response.Duration)
+		if isParseError(err) {
+			metrics.ObserveParseFailure(response.Endpoint)
+		}
+	}
+	s.logResponse(response, logger, dumpDir)
+	for _, hook := range hooks {
+		hook(response)
+	}
+}
+
+// do sends a request that bypasses RequestAPI through the middleware chain and reads the whole body.
+// prepare sets up the request after request hooks, like prepareRequest does for RequestAPI.
+// handle is called with the response and its body, the error is passed to response hooks and returned.
+func (s *Scraper) do(req *http.Request, prepare func(req *http.Request) error, handle func(resp *http.Response, body []byte) error) error {
+	if err := s.runRequestHooks(req); err != nil {
+		return err
+	}
+	if err := prepare(req); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	resp, err := s.httpClient().Do(req)
+	if err != nil {
+		s.runResponseHooks(req, nil, nil, err, start)
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err == nil {
+		err = handle(resp, body)
+	}
+	s.runResponseHooks(req, resp, body, err, start)
+	return err
+}
@@ -0,0 +1,124 @@
+package twitterscraper_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	twitterscraper "github.com/einys/twitter-scraper"
+)
+
+func TestMiddleware(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Test") != "yes" {
+			t.Errorf("Expected header from request hook, got %q", r.Header.Get("X-Test"))
+		}
+		w.Header().Set("X-Rate-Limit-Limit", "150")
+		w.Header().Set("X-Rate-Limit-Remaining", "149")
+		w.Header().Set("X-Rate-Limit-Reset", "1728910000")
+		fmt.Fprint(w, `{"data":{"user":{"result":{"rest_id":"783214","legacy":{"screen_name":"X","name":"X"}}}}}`)
+	})
+
+	var order []string
+	var responses []*twitterscraper.Response
+	scraper := twitterscraper.New().WithBaseURL(server.URL).
+		Use(twitterscraper.Middleware{
+			Request: func(req *http.Request) error {
+				order = append(order, "request 1")
+				return nil
+			},
+			Response: func(resp *twitterscraper.Response) {
+				order = append(order, "response 1")
+				responses = append(responses, resp)
+			},
+		}).
+		Use(twitterscraper.Middleware{
+			Request: func(req *http.Request) error {
+				order = append(order, "request 2")
+				req.Header.Set("X-Test", "yes")
+				return nil
+			},
+			Response: func(resp *twitterscraper.Response) {
+				order = append(order, "response 2")
+			},
+		})
+
+	if _, err := scraper.GetProfile("X"); err != nil {
+		t.Fatal(err)
+	}
+
+	// guest token is activated through the chain while the profile request is prepared
+	expected := "request 1,request 2,request 1,request 2,response 2,response 1,response 2,response 1"
+	if strings.Join(order, ",") != expected {
+		t.Errorf("Expected hooks order %s, got %s", expected, strings.Join(order, ","))
+	}
+	if len(responses) != 2 {
+		t.Fatalf("Expected 2 responses, got %d", len(responses))
+	}
+	if responses[0].Endpoint != "1.1/guest/activate.json" || !strings.Contains(string(responses[0].Body), "guest_token") {
+		t.Errorf("Unexpected guest token response %#v", responses[0])
+	}
+	resp := responses[1]
+	if resp.Endpoint != "UserByScreenName" || resp.StatusCode != http.StatusOK || resp.Err != nil {
+		t.Errorf("Unexpected response %#v", resp)
+	}
+	if !strings.Contains(string(resp.Body), `"rest_id":"783214"`) {
+		t.Errorf("Expected raw body, got %s", resp.Body)
+	}
+	if resp.RateLimit.Remaining != 149 || resp.RateLimit.Reset.Unix() != 1728910000 {
+		t.Errorf("Unexpected rate limit %#v", resp.RateLimit)
+	}
+}
+
+func TestMiddlewareAbort(t *testing.T) {
+	var requests int
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	})
+	errAborted := errors.New("aborted")
+	scraper := twitterscraper.New().WithBaseURL(server.URL).WithRequestHook(func(req *http.Request) error {
+		if strings.HasSuffix(req.URL.Path, "/UserByScreenName") {
+			return errAborted
+		}
+		return nil
+	})
+
+	if _, err := scraper.GetProfile("X"); !errors.Is(err, errAborted) {
+		t.Errorf("Expected error from request hook, got %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("Expected no requests, got %d", requests)
+	}
+}
+
+func TestMiddlewareLoginFlow(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/oauth2/token"):
+			fmt.Fprint(w, `{"access_token":"access"}`)
+		case strings.HasSuffix(r.URL.Path, "/onboarding/task.json"):
+			fmt.Fprint(w, `{"flow_token":"flow","subtasks":[{"subtask_id":"OpenAccount","open_account":{"oauth_token":"token","oauth_token_secret":"secret"}}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	var mu sync.Mutex
+	var endpoints []string
+	scraper := twitterscraper.New().WithBaseURL(server.URL).WithResponseHook(func(resp *twitterscraper.Response) {
+		mu.Lock()
+		endpoints = append(endpoints, fmt.Sprintf("%s %d", resp.Endpoint, resp.StatusCode))
+		mu.Unlock()
+	})
+
+	if _, err := scraper.LoginOpenAccount(); err != nil {
+		t.Fatal(err)
+	}
+	expected := "oauth2/token 200,1.1/guest/activate.json 200,1.1/onboarding/task.json 200,1.1/onboarding/task.json 200"
+	if strings.Join(endpoints, ",") != expected {
+		t.Errorf("Expected responses %s, got %s", expected, strings.Join(endpoints, ","))
+	}
+}
@@ -120,6 +120,92 @@ const (
 
 	TweetJSONSensitiveContent = "sensitive_content"
 
+	TweetJSONEditTweetIDs = "edit_tweet_ids"
+
+	TweetJSONEditableUntil = "editable_until"
+
+	TweetJSONEditsRemaining = "edits_remaining"
+
+	TweetJSONInitialTweetID = "initial_tweet_id"
+
+	TweetJSONIsEditEligible = "is_edit_eligible"
+
+	TweetJSONIsEdit = "is_edit"
+
+	TweetJSONCommunityNote = "community_note"
+
+	TweetJSONPoll = "poll"
+
+	TweetJSONCard = "card"
+
+	// CommunityNote JSON Fields
+
+	CommunityNoteJSONID = "id"
+
+	CommunityNoteJSONText = "text"
+
+	CommunityNoteJSONEntities = "entities"
+
+	CommunityNoteJSONRatingStatus = "rating_status"
+
+	CommunityNoteJSONTitle = "title"
+
+	CommunityNoteJSONURL = "url"
+
+	// NoteEntity JSON Fields
+
+	NoteEntityJSONFromIndex = "from_index"
+
+	NoteEntityJSONToIndex = "to_index"
+
+	NoteEntityJSONURL = "url"
+
+	NoteEntityJSONType = "type"
+
+	// Poll JSON Fields
+
+	PollJSONID = "id"
+
+	PollJSONChoices = "choices"
+
+	PollJSONDurationMinutes = "duration_minutes"
+
+	PollJSONEndDateTime = "end_datetime"
+
+	PollJSONCountsAreFinal = "counts_are_final"
+
+	// PollChoice JSON Fields
+
+	PollChoiceJSONLabel = "label"
+
+	PollChoiceJSONCount = "count"
+
+	// Card JSON Fields
+
+	CardJSONName = "name"
+
+	CardJSONURL = "url"
+
+	CardJSONTitle = "title"
+
+	CardJSONDescription = "description"
+
+	CardJSONDomain = "domain"
+
+	CardJSONValues = "values"
+
+	CardJSONImages = "images"
+
+	// CardImage JSON Fields
+
+	CardImageJSONURL = "url"
+
+	CardImageJSONWidth = "width"
+
+	CardImageJSONHeight = "height"
+
+	CardImageJSONAlt = "alt"
+
 	// ProfileResult JSON Fields
 
 	ProfileResultJSONError = "error"
@@ -320,6 +406,12 @@ const (
 
 	legacyTweetJSONViews = "ext_views"
 
+	legacyTweetJSONEditControl = "edit_control"
+
+	legacyTweetJSONCard = "card"
+
+	legacyTweetJSONExt = "ext"
+
 	// Place JSON Fields
 
 	PlaceJSONID = "id"
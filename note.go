@@ -0,0 +1,78 @@
+package twitterscraper
+
+import "strings"
+
+// Rating statuses of Community Notes
+const (
+	// NoteCurrentlyRatedHelpful - note shown to everyone under the tweet
+	NoteCurrentlyRatedHelpful = "CURRENTLY_RATED_HELPFUL"
+	// NoteNeedsMoreRatings - proposed note shown to contributors for rating
+	NoteNeedsMoreRatings = "NEEDS_MORE_RATINGS"
+)
+
+// noteVisualStyles map visual styles of pivots to rating statuses of their notes
+var noteVisualStyles = map[string]string{
+	"Default":   NoteCurrentlyRatedHelpful,
+	"Tentative": NoteNeedsMoreRatings,
+}
+
+// birdwatchPivot of GraphQL results and of legacy tweets requested with `ext=birdwatchPivot`
+type birdwatchPivot struct {
+	DestinationURL string `json:"destinationUrl"`
+	Note           struct {
+		RestID string `json:"rest_id"`
+		// RestIDLegacy of legacy timelines
+		RestIDLegacy string `json:"restId"`
+		RatingStatus string `json:"rating_status"`
+	} `json:"note"`
+	ShortTitle string `json:"shorttitle"`
+	Title      string `json:"title"`
+	Subtitle   struct {
+		Text     string `json:"text"`
+		Entities []struct {
+			FromIndex int `json:"fromIndex"`
+			ToIndex   int `json:"toIndex"`
+			Ref       struct {
+				Type    string `json:"type"`
+				URL     string `json:"url"`
+				URLType string `json:"urlType"`
+			} `json:"ref"`
+		} `json:"entities"`
+	} `json:"subtitle"`
+	VisualStyle string `json:"visualStyle"`
+}
+
+// parse returns the note of the pivot, nil if there is none.
+func (pivot *birdwatchPivot) parse() *CommunityNote {
+	if pivot == nil || (pivot.Subtitle.Text == "" && pivot.DestinationURL == "") {
+		return nil
+	}
+	note := &CommunityNote{
+		ID:    pivot.Note.RestID,
+		Text:  pivot.Subtitle.Text,
+		Title: pivot.Title,
+		URL:   pivot.DestinationURL,
+	}
+	if note.ID == "" {
+		note.ID = pivot.Note.RestIDLegacy
+	}
+	if note.ID == "" {
+		// https://twitter.com/i/birdwatch/n/1234
+		if i := strings.LastIndex(note.URL, "/n/"); i >= 0 {
+			note.ID = strings.SplitN(note.URL[i+3:], "?", 2)[0]
+		}
+	}
+	for _, entity := range pivot.Subtitle.Entities {
+		note.Entities = append(note.Entities, NoteEntity{
+			FromIndex: entity.FromIndex,
+			ToIndex:   entity.ToIndex,
+			URL:       entity.Ref.URL,
+			Type:      entity.Ref.URLType,
+		})
+	}
+	note.RatingStatus = pivot.Note.RatingStatus
+	if note.RatingStatus == "" {
+		note.RatingStatus = noteVisualStyles[pivot.VisualStyle]
+	}
+	return note
+}
@@ -0,0 +1,71 @@
+package twitterscraper_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	twitterscraper "github.com/einys/twitter-scraper"
+)
+
+const birdwatchPivot = `{"destinationUrl":"https://twitter.com/i/birdwatch/n/1700000000000000000","note":{"rest_id":"1700000000000000000"},"shorttitle":"Readers added context","title":"Readers added context they thought people might want to know","subtitle":{"text":"The photo is from 2015. reuters.com/fact-check","entities":[{"fromIndex":23,"toIndex":46,"ref":{"type":"TimelineUrl","url":"https://t.co/abc","urlType":"ExternalUrl"}}]},"visualStyle":"Default"}`
+
+func TestCommunityNote(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/TweetResultByRestId"):
+			fmt.Fprintf(w, `{"data":{"tweetResult":{"result":{"__typename":"TweetWithVisibilityResults","tweet":{"rest_id":"1","core":{"user_results":{"result":{"core":{"screen_name":"X","name":"X"}}}},"birdwatch_pivot":%s,"legacy":{"id_str":"1","full_text":"photo"}}}}}}`, birdwatchPivot)
+		case strings.HasSuffix(r.URL.Path, "/timeline/conversation/1.json"):
+			pivot := strings.Replace(birdwatchPivot, `"rest_id"`, `"restId"`, 1)
+			pivot = strings.Replace(pivot, "Readers added context they thought people might want to know", "Rate proposed Community Notes", 1)
+			pivot = strings.Replace(pivot, `"visualStyle":"Default"`, `"visualStyle":"Tentative"`, 1)
+			fmt.Fprintf(w, `{"globalObjects":{"tweets":{"1":{"id_str":"1","full_text":"photo","user_id_str":"1","ext":{"birdwatchPivot":{"r":{"ok":%s}}}}},"users":{"1":{"screen_name":"X","name":"X"}}},"timeline":{"instructions":[{"addEntries":{"entries":[{"content":{"item":{"content":{"tweet":{"id":"1"}}}}}]}}]}}`, pivot)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	scraper := twitterscraper.New().WithBaseURL(server.URL)
+	tweet, err := scraper.GetTweet("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	note := tweet.CommunityNote
+	if note == nil {
+		t.Fatal("Expected community note")
+	}
+	if note.ID != "1700000000000000000" || note.Text != "The photo is from 2015. reuters.com/fact-check" ||
+		note.URL != "https://twitter.com/i/birdwatch/n/1700000000000000000" || note.RatingStatus != twitterscraper.NoteCurrentlyRatedHelpful {
+		t.Errorf("Unexpected note %+v", note)
+	}
+	if len(note.Entities) != 1 || note.Entities[0].FromIndex != 23 || note.Entities[0].ToIndex != 46 ||
+		note.Entities[0].URL != "https://t.co/abc" || note.Entities[0].Type != "ExternalUrl" {
+		t.Errorf("Unexpected note entities %+v", note.Entities)
+	}
+
+	scraper.WithOpenAccount(twitterscraper.OpenAccount{OAuthToken: "token", OAuthTokenSecret: "secret"})
+	tweet, err = scraper.GetTweet("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if note := tweet.CommunityNote; note == nil || note.ID != "1700000000000000000" || note.RatingStatus != twitterscraper.NoteNeedsMoreRatings {
+		t.Errorf("Unexpected note of legacy tweet %+v", note)
+	}
+}
+
+func TestCommunityNoteUnknownStatus(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		// status isn't guessed from the title without a known visual style
+		pivot := strings.Replace(birdwatchPivot, `"visualStyle":"Default"`, `"visualStyle":"Unknown"`, 1)
+		fmt.Fprintf(w, `{"data":{"tweetResult":{"result":{"__typename":"Tweet","rest_id":"1","core":{"user_results":{"result":{"core":{"screen_name":"X","name":"X"}}}},"birdwatch_pivot":%s,"legacy":{"id_str":"1","full_text":"photo"}}}}}`, pivot)
+	})
+
+	tweet, err := twitterscraper.New().WithBaseURL(server.URL).GetTweet("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if note := tweet.CommunityNote; note == nil || note.RatingStatus != "" {
+		t.Errorf("Expected note without rating status, got %+v", note)
+	}
+}
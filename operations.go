@@ -0,0 +1,285 @@
+package twitterscraper
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Operation describes a GraphQL request of the Twitter web client.
+type Operation struct {
+	// Name of the operation, like `UserTweets`
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// Path of the URL, Name if empty, for operations sharing the endpoint of another one.
+	// Overrides and discovered query IDs of the operation named Path apply to it too.
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	// QueryID rotated by Twitter from time to time
+	QueryID string `json:"query_id,omitempty" yaml:"query_id,omitempty"`
+	// Method is GET or POST
+	Method string `json:"method,omitempty" yaml:"method,omitempty"`
+	// BaseURL the request is sent to, `https://twitter.com/i/api/graphql/` if empty
+	BaseURL string `json:"base_url,omitempty" yaml:"base_url,omitempty"`
+	// Variables sent unless the method sets them itself, like `count` or `cursor`
+	Variables map[string]interface{} `json:"variables,omitempty" yaml:"variables,omitempty"`
+	// Features flags of the web client
+	Features map[string]interface{} `json:"features,omitempty" yaml:"features,omitempty"`
+	// FieldToggles of the web client
+	FieldToggles map[string]interface{} `json:"field_toggles,omitempty" yaml:"field_toggles,omitempty"`
+}
+
+// URL of the operation, like `https://twitter.com/i/api/graphql/{QueryID}/{Name}`
+func (op Operation) URL() string {
+	baseURL := op.BaseURL
+	if baseURL == "" {
+		baseURL = graphQLURL
+	}
+	path := op.Path
+	if path == "" {
+		path = op.Name
+	}
+	return strings.TrimSuffix(baseURL, "/") + "/" + op.QueryID + "/" + path
+}
+
+// encode sets variables merged over the defaults, features and field toggles to the query of GET request or the body of POST request.
+func (op Operation) encode(req *http.Request, variables map[string]interface{}) {
+	merged := copyMap(op.Variables)
+	for key, value := range variables {
+		merged[key] = value
+	}
+
+	if req.Method != "POST" {
+		query := url.Values{}
+		query.Set("variables", mapToJSONString(merged))
+		if len(op.Features) > 0 {
+			query.Set("features", mapToJSONString(op.Features))
+		}
+		if len(op.FieldToggles) > 0 {
+			query.Set("fieldToggles", mapToJSONString(op.FieldToggles))
+		}
+		req.URL.RawQuery = query.Encode()
+		return
+	}
+
+	body := map[string]interface{}{
+		"variables": merged,
+		"queryId":   op.QueryID,
+	}
+	if len(op.Features) > 0 {
+		body["features"] = op.Features
+	}
+	if len(op.FieldToggles) > 0 {
+		body["fieldToggles"] = op.FieldToggles
+	}
+	b, _ := json.Marshal(body)
+	req.Header.Set("Content-Type", "application/json")
+	req.Body = io.NopCloser(bytes.NewReader(b))
+	req.ContentLength = int64(len(b))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(b)), nil
+	}
+}
+
+// OperationRegistry holds GraphQL operations used by Scraper methods, safe for concurrent use.
+// Override query IDs and flags when Twitter rotates them without waiting for a release.
+type OperationRegistry struct {
+	mu         sync.RWMutex
+	operations map[string]Operation
+}
+
+// NewOperationRegistry creates registry with built-in operations.
+func NewOperationRegistry() *OperationRegistry {
+	r := &OperationRegistry{operations: make(map[string]Operation)}
+	for _, op := range defaultOperations() {
+		r.operations[op.Name] = op
+	}
+	return r
+}
+
+// Get returns operation by name.
+func (r *OperationRegistry) Get(name string) (Operation, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	op, ok := r.operations[name]
+	return op.copy(), ok
+}
+
+// Operations returns all operations sorted by name.
+func (r *OperationRegistry) Operations() []Operation {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ops := make([]Operation, 0, len(r.operations))
+	for _, op := range r.operations {
+		ops = append(ops, op.copy())
+	}
+	sort.Slice(ops, func(i, j int) bool { return ops[i].Name < ops[j].Name })
+	return ops
+}
+
+// Set add or replace operation as a whole.
+func (r *OperationRegistry) Set(op Operation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.operations[op.Name] = op.copy()
+}
+
+// Override update non-empty fields of the operation, Variables, Features and FieldToggles are merged key by key.
+// Unknown operations are added. Operations sharing the endpoint, with Path equal to the name, are updated too.
+func (r *OperationRegistry) Override(op Operation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	current := r.operations[op.Name].copy()
+	current.Name = op.Name
+	if op.Path != "" {
+		current.Path = op.Path
+	}
+	r.operations[op.Name] = current.override(op)
+	for name, alias := range r.operations {
+		if alias.Path == op.Name && name != op.Name {
+			r.operations[name] = alias.copy().override(op)
+		}
+	}
+}
+
+// override returns the operation with non-empty fields of with, keeping its name and path.
+func (op Operation) override(with Operation) Operation {
+	if with.QueryID != "" {
+		op.QueryID = with.QueryID
+	}
+	if with.Method != "" {
+		op.Method = strings.ToUpper(with.Method)
+	}
+	if with.BaseURL != "" {
+		op.BaseURL = with.BaseURL
+	}
+	op.Variables = mergeMaps(op.Variables, with.Variables)
+	op.Features = mergeMaps(op.Features, with.Features)
+	op.FieldToggles = mergeMaps(op.FieldToggles, with.FieldToggles)
+	return op
+}
+
+// SetQueryID replace query ID of the operation.
+func (r *OperationRegistry) SetQueryID(name, queryID string) {
+	r.Override(Operation{Name: name, QueryID: queryID})
+}
+
+// LoadJSON override operations from JSON object keyed by operation name, see Override.
+//
+//	{"UserTweets": {"query_id": "E3opETHurmVJflFsUBVuUQ", "features": {"rweb_video_timestamps_enabled": false}}}
+func (r *OperationRegistry) LoadJSON(reader io.Reader) error {
+	var ops map[string]Operation
+	if err := json.NewDecoder(reader).Decode(&ops); err != nil {
+		return fmt.Errorf("decode operations: %w", err)
+	}
+	r.overrideAll(ops)
+	return nil
+}
+
+// LoadYAML override operations from YAML mapping keyed by operation name, see LoadJSON.
+func (r *OperationRegistry) LoadYAML(reader io.Reader) error {
+	var ops map[string]Operation
+	if err := yaml.NewDecoder(reader).Decode(&ops); err != nil && err != io.EOF {
+		return fmt.Errorf("decode operations: %w", err)
+	}
+	r.overrideAll(ops)
+	return nil
+}
+
+// LoadFile override operations from .json, .yaml or .yml file.
+func (r *OperationRegistry) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return r.LoadJSON(f)
+	case ".yaml", ".yml":
+		return r.LoadYAML(f)
+	}
+	return fmt.Errorf("unsupported operations file %s, expected .json, .yaml or .yml", path)
+}
+
+// update replaces the operation and the ones sharing its endpoint with the result of fn under a single lock.
+func (r *OperationRegistry) update(name string, fn func(op Operation, ok bool) Operation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for alias, op := range r.operations {
+		if op.Path == name && alias != name {
+			r.operations[alias] = fn(op.copy(), true).copy()
+		}
+	}
+	op, ok := r.operations[name]
+	r.operations[name] = fn(op.copy(), ok).copy()
+}
+
+func (r *OperationRegistry) overrideAll(ops map[string]Operation) {
+	for name, op := range ops {
+		op.Name = name
+		r.Override(op)
+	}
+}
+
+// Operations returns the registry of GraphQL operations used by the scraper.
+func (s *Scraper) Operations() *OperationRegistry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.operations
+}
+
+// WithOperations use the registry, e.g. to share overrides between scrapers.
+func (s *Scraper) WithOperations(registry *OperationRegistry) *Scraper {
+	s.mu.Lock()
+	s.operations = registry
+	s.mu.Unlock()
+	return s
+}
+
+// operation returns the operation from the registry, or the built-in one if it was never registered.
+func (s *Scraper) operation(name string) Operation {
+	if op, ok := s.Operations().Get(name); ok {
+		return op
+	}
+	for _, op := range defaultOperations() {
+		if op.Name == name {
+			return op
+		}
+	}
+	return Operation{Name: name, Method: "GET"}
+}
+
+func (op Operation) copy() Operation {
+	op.Variables = copyMap(op.Variables)
+	op.Features = copyMap(op.Features)
+	op.FieldToggles = copyMap(op.FieldToggles)
+	return op
+}
+
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	c := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		c[key] = value
+	}
+	return c
+}
+
+func mergeMaps(dst, src map[string]interface{}) map[string]interface{} {
+	if dst == nil {
+		dst = make(map[string]interface{}, len(src))
+	}
+	for key, value := range src {
+		dst[key] = value
+	}
+	return dst
+}
@@ -0,0 +1,524 @@
+package twitterscraper
+
+// graphQLURL is the default base URL of GraphQL operations
+const graphQLURL = "https://twitter.com/i/api/graphql/"
+
+// defaultOperations returns built-in GraphQL operations, every call returns new maps.
+func defaultOperations() []Operation {
+	usersFeatures := func() map[string]interface{} {
+		return map[string]interface{}{
+			"responsive_web_graphql_exclude_directive_enabled":                        true,
+			"verified_phone_label_enabled":                                            false,
+			"creator_subscriptions_tweet_preview_api_enabled":                         true,
+			"responsive_web_graphql_timeline_navigation_enabled":                      true,
+			"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
+			"c9s_tweet_anatomy_moderator_badge_enabled":                               true,
+			"tweetypie_unmention_optimization_enabled":                                true,
+			"responsive_web_edit_tweet_api_enabled":                                   true,
+			"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
+			"view_counts_everywhere_api_enabled":                                      true,
+			"longform_notetweets_consumption_enabled":                                 true,
+			"responsive_web_twitter_article_tweet_consumption_enabled":                true,
+			"tweet_awards_web_tipping_enabled":                                        false,
+			"freedom_of_speech_not_reach_fetch_enabled":                               true,
+			"standardized_nudges_misinfo":                                             true,
+			"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": true,
+			"rweb_video_timestamps_enabled":                                           true,
+			"longform_notetweets_rich_text_read_enabled":                              true,
+			"longform_notetweets_inline_media_enabled":                                true,
+			"responsive_web_enhance_cards_enabled":                                    false,
+		}
+	}
+	conversationFeatures := func() map[string]interface{} {
+		return map[string]interface{}{
+			"rweb_tipjar_consumption_enabled":                                         true,
+			"responsive_web_graphql_exclude_directive_enabled":                        true,
+			"verified_phone_label_enabled":                                            false,
+			"creator_subscriptions_tweet_preview_api_enabled":                         true,
+			"responsive_web_graphql_timeline_navigation_enabled":                      true,
+			"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
+			"communities_web_enable_tweet_community_results_fetch":                    true,
+			"c9s_tweet_anatomy_moderator_badge_enabled":                               true,
+			"articles_preview_enabled":                                                true,
+			"tweetypie_unmention_optimization_enabled":                                true,
+			"responsive_web_edit_tweet_api_enabled":                                   true,
+			"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
+			"view_counts_everywhere_api_enabled":                                      true,
+			"longform_notetweets_consumption_enabled":                                 true,
+			"responsive_web_twitter_article_tweet_consumption_enabled":                true,
+			"tweet_awards_web_tipping_enabled":                                        false,
+			"creator_subscriptions_quote_tweet_preview_enabled":                       false,
+			"freedom_of_speech_not_reach_fetch_enabled":                               true,
+			"standardized_nudges_misinfo":                                             true,
+			"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": true,
+			"rweb_video_timestamps_enabled":                                           true,
+			"longform_notetweets_rich_text_read_enabled":                              true,
+			"longform_notetweets_inline_media_enabled":                                true,
+			"responsive_web_enhance_cards_enabled":                                    false,
+		}
+	}
+	timelineFeatures := func() map[string]interface{} {
+		return map[string]interface{}{
+			"rweb_tipjar_consumption_enabled":                                         true,
+			"responsive_web_graphql_exclude_directive_enabled":                        true,
+			"verified_phone_label_enabled":                                            false,
+			"creator_subscriptions_tweet_preview_api_enabled":                         true,
+			"responsive_web_graphql_timeline_navigation_enabled":                      true,
+			"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
+			"communities_web_enable_tweet_community_results_fetch":                    true,
+			"c9s_tweet_anatomy_moderator_badge_enabled":                               true,
+			"articles_preview_enabled":                                                true,
+			"responsive_web_edit_tweet_api_enabled":                                   true,
+			"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
+			"view_counts_everywhere_api_enabled":                                      true,
+			"longform_notetweets_consumption_enabled":                                 true,
+			"responsive_web_twitter_article_tweet_consumption_enabled":                true,
+			"tweet_awards_web_tipping_enabled":                                        false,
+			"creator_subscriptions_quote_tweet_preview_enabled":                       false,
+			"freedom_of_speech_not_reach_fetch_enabled":                               true,
+			"standardized_nudges_misinfo":                                             true,
+			"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": true,
+			"rweb_video_timestamps_enabled":                                           true,
+			"longform_notetweets_rich_text_read_enabled":                              true,
+			"longform_notetweets_inline_media_enabled":                                true,
+			"responsive_web_enhance_cards_enabled":                                    false,
+		}
+	}
+
+	return []Operation{
+		{
+			Name:    "Bookmarks",
+			QueryID: "-IyJFt9_jS_9d_vS3NN-fA",
+			Method:  "GET",
+			Variables: map[string]interface{}{
+				"includePromotedContent": false,
+			},
+			Features: map[string]interface{}{
+				"graphql_timeline_v2_bookmark_timeline":                                   true,
+				"responsive_web_graphql_exclude_directive_enabled":                        true,
+				"verified_phone_label_enabled":                                            false,
+				"creator_subscriptions_tweet_preview_api_enabled":                         true,
+				"responsive_web_graphql_timeline_navigation_enabled":                      true,
+				"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
+				"c9s_tweet_anatomy_moderator_badge_enabled":                               true,
+				"tweetypie_unmention_optimization_enabled":                                true,
+				"responsive_web_edit_tweet_api_enabled":                                   true,
+				"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
+				"view_counts_everywhere_api_enabled":                                      true,
+				"longform_notetweets_consumption_enabled":                                 true,
+				"responsive_web_twitter_article_tweet_consumption_enabled":                true,
+				"tweet_awards_web_tipping_enabled":                                        false,
+				"freedom_of_speech_not_reach_fetch_enabled":                               true,
+				"standardized_nudges_misinfo":                                             true,
+				"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": true,
+				"rweb_video_timestamps_enabled":                                           true,
+				"longform_notetweets_rich_text_read_enabled":                              true,
+				"longform_notetweets_inline_media_enabled":                                true,
+				"responsive_web_enhance_cards_enabled":                                    false,
+			},
+		},
+		{
+			Name:    "Following",
+			QueryID: "g5P4cbXR4ta4oCeE7y2vLQ",
+			Method:  "GET",
+			Variables: map[string]interface{}{
+				"includePromotedContent": false,
+			},
+			Features: usersFeatures(),
+		},
+		{
+			Name:    "Followers",
+			QueryID: "jwbfbSzn0FRL_AMZGsYDag",
+			Method:  "GET",
+			Variables: map[string]interface{}{
+				"includePromotedContent": false,
+			},
+			Features: usersFeatures(),
+		},
+		{
+			Name:    "UserMedia",
+			QueryID: "2tLOJWwGuCTytDrGBg8VwQ",
+			Method:  "GET",
+			Variables: map[string]interface{}{
+				"includePromotedContent": false,
+				"withClientEventToken":   false,
+				"withBirdwatchNotes":     false,
+				"withVoice":              true,
+				"withV2Timeline":         true,
+			},
+			Features: map[string]interface{}{
+				"responsive_web_graphql_exclude_directive_enabled":                        true,
+				"verified_phone_label_enabled":                                            false,
+				"creator_subscriptions_tweet_preview_api_enabled":                         true,
+				"responsive_web_graphql_timeline_navigation_enabled":                      true,
+				"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
+				"c9s_tweet_anatomy_moderator_badge_enabled":                               true,
+				"tweetypie_unmention_optimization_enabled":                                true,
+				"responsive_web_edit_tweet_api_enabled":                                   true,
+				"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
+				"view_counts_everywhere_api_enabled":                                      true,
+				"longform_notetweets_consumption_enabled":                                 true,
+				"responsive_web_twitter_article_tweet_consumption_enabled":                true,
+				"tweet_awards_web_tipping_enabled":                                        false,
+				"freedom_of_speech_not_reach_fetch_enabled":                               true,
+				"standardized_nudges_misinfo":                                             true,
+				"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": true,
+				"rweb_video_timestamps_enabled":                                           true,
+				"longform_notetweets_rich_text_read_enabled":                              true,
+				"longform_notetweets_inline_media_enabled":                                true,
+				"responsive_web_media_download_video_enabled":                             false,
+				"responsive_web_enhance_cards_enabled":                                    false,
+			},
+		},
+		{
+			Name:    "UserByScreenName",
+			QueryID: "Yka-W8dz7RaEuQNkroPkYw",
+			Method:  "GET",
+			BaseURL: "https://api.twitter.com/graphql/",
+			Variables: map[string]interface{}{
+				"withSafetyModeUserFields": true,
+			},
+			Features: map[string]interface{}{
+				"hidden_profile_subscriptions_enabled":                              true,
+				"rweb_tipjar_consumption_enabled":                                   true,
+				"responsive_web_graphql_exclude_directive_enabled":                  true,
+				"verified_phone_label_enabled":                                      false,
+				"subscriptions_verification_info_is_identity_verified_enabled":      true,
+				"subscriptions_verification_info_verified_since_enabled":            true,
+				"highlights_tweets_tab_ui_enabled":                                  true,
+				"responsive_web_twitter_article_notes_tab_enabled":                  true,
+				"subscriptions_feature_can_gift_premium":                            true,
+				"creator_subscriptions_tweet_preview_api_enabled":                   true,
+				"responsive_web_graphql_skip_user_profile_image_extensions_enabled": false,
+				"responsive_web_graphql_timeline_navigation_enabled":                true,
+			},
+		},
+		{
+			Name:    "UserByRestId",
+			QueryID: "Qw77dDjp9xCpUY-AXwt-yQ",
+			Method:  "GET",
+			Variables: map[string]interface{}{
+				"withSafetyModeUserFields": true,
+			},
+			Features: map[string]interface{}{
+				"hidden_profile_subscriptions_enabled":                              true,
+				"rweb_tipjar_consumption_enabled":                                   true,
+				"responsive_web_graphql_exclude_directive_enabled":                  true,
+				"verified_phone_label_enabled":                                      false,
+				"highlights_tweets_tab_ui_enabled":                                  true,
+				"responsive_web_twitter_article_notes_tab_enabled":                  true,
+				"subscriptions_feature_can_gift_premium":                            true,
+				"creator_subscriptions_tweet_preview_api_enabled":                   true,
+				"responsive_web_graphql_skip_user_profile_image_extensions_enabled": false,
+				"responsive_web_graphql_timeline_navigation_enabled":                true,
+			},
+		},
+		{
+			Name:    "TweetDetail",
+			QueryID: "ldqoq5MmFHN1FhMGvzC9Jg",
+			Method:  "GET",
+			Variables: map[string]interface{}{
+				"referrer":                               "tweet",
+				"with_rux_injections":                    false,
+				"rankingMode":                            "Relevance",
+				"includePromotedContent":                 true,
+				"withCommunity":                          true,
+				"withQuickPromoteEligibilityTweetFields": true,
+				"withBirdwatchNotes":                     true,
+				"withVoice":                              true,
+			},
+			Features: conversationFeatures(),
+			FieldToggles: map[string]interface{}{
+				"withArticleRichContentState": true,
+				"withArticlePlainText":        false,
+				"withGrokAnalyze":             false,
+				"withDisallowedReplyControls": false,
+			},
+		},
+		{
+			// TweetDetail of a single tweet, as requested by GetTweet
+			Name:    "TweetDetailSingle",
+			Path:    "TweetDetail",
+			QueryID: "VWFGPVAGkZMGRKGe3GFFnA",
+			Method:  "GET",
+			Variables: map[string]interface{}{
+				"with_rux_injections":                    false,
+				"includePromotedContent":                 true,
+				"withCommunity":                          true,
+				"withQuickPromoteEligibilityTweetFields": true,
+				"withBirdwatchNotes":                     true,
+				"withVoice":                              true,
+				"withV2Timeline":                         true,
+			},
+			Features: map[string]interface{}{
+				"rweb_lists_timeline_redesign_enabled":                                    true,
+				"responsive_web_graphql_exclude_directive_enabled":                        true,
+				"verified_phone_label_enabled":                                            false,
+				"creator_subscriptions_tweet_preview_api_enabled":                         true,
+				"responsive_web_graphql_timeline_navigation_enabled":                      true,
+				"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
+				"tweetypie_unmention_optimization_enabled":                                true,
+				"responsive_web_edit_tweet_api_enabled":                                   true,
+				"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
+				"view_counts_everywhere_api_enabled":                                      true,
+				"longform_notetweets_consumption_enabled":                                 true,
+				"tweet_awards_web_tipping_enabled":                                        false,
+				"freedom_of_speech_not_reach_fetch_enabled":                               true,
+				"standardized_nudges_misinfo":                                             true,
+				"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": false,
+				"longform_notetweets_rich_text_read_enabled":                              true,
+				"longform_notetweets_inline_media_enabled":                                true,
+				"responsive_web_enhance_cards_enabled":                                    false,
+			},
+		},
+		{
+			Name:    "FetchScheduledTweets",
+			QueryID: "ITtjAzvlZni2wWXwf295Qg",
+			Method:  "GET",
+			Variables: map[string]interface{}{
+				"ascending": true,
+			},
+		},
+		{
+			Name:    "DeleteScheduledTweet",
+			QueryID: "CTOVqej0JBXAZSwkp1US0g",
+			Method:  "POST",
+		},
+		{
+			Name:    "CreateScheduledTweet",
+			QueryID: "LCVzRQGxOaGnOnYH01NQXg",
+			Method:  "POST",
+		},
+		{
+			Name:    "SearchTimeline",
+			QueryID: "nK1dw4oV3k4w5TdtcAdSww",
+			Method:  "GET",
+			Variables: map[string]interface{}{
+				"querySource": "typed_query",
+				"product":     "Top",
+			},
+			Features: map[string]interface{}{
+				"rweb_lists_timeline_redesign_enabled":                                    true,
+				"responsive_web_graphql_exclude_directive_enabled":                        true,
+				"verified_phone_label_enabled":                                            false,
+				"creator_subscriptions_tweet_preview_api_enabled":                         true,
+				"responsive_web_graphql_timeline_navigation_enabled":                      true,
+				"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
+				"tweetypie_unmention_optimization_enabled":                                true,
+				"responsive_web_edit_tweet_api_enabled":                                   true,
+				"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
+				"view_counts_everywhere_api_enabled":                                      true,
+				"longform_notetweets_consumption_enabled":                                 true,
+				"responsive_web_twitter_article_tweet_consumption_enabled":                false,
+				"tweet_awards_web_tipping_enabled":                                        false,
+				"freedom_of_speech_not_reach_fetch_enabled":                               true,
+				"standardized_nudges_misinfo":                                             true,
+				"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": true,
+				"longform_notetweets_rich_text_read_enabled":                              true,
+				"longform_notetweets_inline_media_enabled":                                true,
+				"responsive_web_media_download_video_enabled":                             false,
+				"responsive_web_enhance_cards_enabled":                                    false,
+			},
+			FieldToggles: map[string]interface{}{
+				"withArticleRichContentState": false,
+			},
+		},
+		{
+			Name:    "AudioSpaceById",
+			QueryID: "d03OdorPdZ_sH9V3D1_yWQ",
+			Method:  "GET",
+			Variables: map[string]interface{}{
+				"isMetatagsQuery": false,
+				"withReplays":     true,
+				"withListeners":   true,
+			},
+			Features: map[string]interface{}{
+				"spaces_2022_h2_spaces_communities":                                       true,
+				"spaces_2022_h2_clipping":                                                 true,
+				"creator_subscriptions_tweet_preview_api_enabled":                         true,
+				"rweb_tipjar_consumption_enabled":                                         true,
+				"responsive_web_graphql_exclude_directive_enabled":                        true,
+				"verified_phone_label_enabled":                                            false,
+				"communities_web_enable_tweet_community_results_fetch":                    true,
+				"c9s_tweet_anatomy_moderator_badge_enabled":                               true,
+				"articles_preview_enabled":                                                true,
+				"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
+				"tweetypie_unmention_optimization_enabled":                                true,
+				"responsive_web_edit_tweet_api_enabled":                                   true,
+				"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
+				"view_counts_everywhere_api_enabled":                                      true,
+				"longform_notetweets_consumption_enabled":                                 true,
+				"responsive_web_twitter_article_tweet_consumption_enabled":                true,
+				"tweet_awards_web_tipping_enabled":                                        false,
+				"creator_subscriptions_quote_tweet_preview_enabled":                       false,
+				"freedom_of_speech_not_reach_fetch_enabled":                               true,
+				"standardized_nudges_misinfo":                                             true,
+				"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": true,
+				"rweb_video_timestamps_enabled":                                           true,
+				"longform_notetweets_rich_text_read_enabled":                              true,
+				"longform_notetweets_inline_media_enabled":                                true,
+				"responsive_web_graphql_timeline_navigation_enabled":                      true,
+				"responsive_web_enhance_cards_enabled":                                    false,
+			},
+		},
+		{
+			Name:    "CreateTweet",
+			QueryID: "oB-5XsHNAbjvARJEc8CZFw",
+			Method:  "POST",
+			Variables: map[string]interface{}{
+				"dark_request": false,
+			},
+			Features: map[string]interface{}{
+				"communities_web_enable_tweet_community_results_fetch":                    true,
+				"c9s_tweet_anatomy_moderator_badge_enabled":                               true,
+				"tweetypie_unmention_optimization_enabled":                                true,
+				"responsive_web_edit_tweet_api_enabled":                                   true,
+				"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
+				"view_counts_everywhere_api_enabled":                                      true,
+				"longform_notetweets_consumption_enabled":                                 true,
+				"responsive_web_twitter_article_tweet_consumption_enabled":                true,
+				"tweet_awards_web_tipping_enabled":                                        false,
+				"creator_subscriptions_quote_tweet_preview_enabled":                       false,
+				"longform_notetweets_rich_text_read_enabled":                              true,
+				"longform_notetweets_inline_media_enabled":                                true,
+				"articles_preview_enabled":                                                true,
+				"rweb_video_timestamps_enabled":                                           true,
+				"rweb_tipjar_consumption_enabled":                                         true,
+				"responsive_web_graphql_exclude_directive_enabled":                        true,
+				"verified_phone_label_enabled":                                            false,
+				"freedom_of_speech_not_reach_fetch_enabled":                               true,
+				"standardized_nudges_misinfo":                                             true,
+				"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": true,
+				"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
+				"responsive_web_graphql/_timeline_navigation_enabled":                     true,
+				"responsive_web_enhance_cards_enabled":                                    false,
+			},
+		},
+		{
+			Name:    "DeleteTweet",
+			QueryID: "VaenaVgh5q5ih7kvyVjgtg",
+			Method:  "POST",
+			Variables: map[string]interface{}{
+				"dark_request": false,
+			},
+		},
+		{
+			Name:    "CreateRetweet",
+			QueryID: "ojPdsZsimiJrUGLR1sjUtA",
+			Method:  "POST",
+			Variables: map[string]interface{}{
+				"dark_request": false,
+			},
+		},
+		{
+			Name:    "DeleteRetweet",
+			QueryID: "iQtK4dl5hBmXewYZuEOKVw",
+			Method:  "POST",
+			Variables: map[string]interface{}{
+				"dark_request": false,
+			},
+		},
+		{
+			Name:    "FavoriteTweet",
+			QueryID: "lI07N6Otwv1PhnEgXILM7A",
+			Method:  "POST",
+		},
+		{
+			Name:    "UnfavoriteTweet",
+			QueryID: "ZYKSe-w7KEslx3JhSIk5LA",
+			Method:  "POST",
+		},
+		{
+			Name:    "Retweeters",
+			QueryID: "8019obfgnveiPiJuS2Rtow",
+			Method:  "GET",
+			Variables: map[string]interface{}{
+				"includePromotedContent": false,
+			},
+			Features: timelineFeatures(),
+		},
+		{
+			Name:    "UserTweetsAndReplies",
+			QueryID: "bt4TKuFz4T7Ckk-VvQVSow",
+			Method:  "GET",
+			Variables: map[string]interface{}{
+				"includePromotedContent":                 false,
+				"withQuickPromoteEligibilityTweetFields": false,
+				"withVoice":                              true,
+				"withV2Timeline":                         true,
+			},
+			Features: timelineFeatures(),
+		},
+		{
+			Name:    "UserTweets",
+			QueryID: "UGi7tjRPr-d_U3bCPIko5Q",
+			Method:  "GET",
+			Variables: map[string]interface{}{
+				"includePromotedContent":                 false,
+				"withQuickPromoteEligibilityTweetFields": false,
+				"withVoice":                              true,
+				"withV2Timeline":                         true,
+			},
+			Features: map[string]interface{}{
+				"rweb_lists_timeline_redesign_enabled":                              true,
+				"responsive_web_graphql_exclude_directive_enabled":                  true,
+				"verified_phone_label_enabled":                                      false,
+				"creator_subscriptions_tweet_preview_api_enabled":                   true,
+				"responsive_web_graphql_timeline_navigation_enabled":                true,
+				"responsive_web_graphql_skip_user_profile_image_extensions_enabled": false,
+				"tweetypie_unmention_optimization_enabled":                          true,
+				"vibe_api_enabled":                                                        true,
+				"responsive_web_edit_tweet_api_enabled":                                   true,
+				"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
+				"view_counts_everywhere_api_enabled":                                      true,
+				"longform_notetweets_consumption_enabled":                                 true,
+				"tweet_awards_web_tipping_enabled":                                        false,
+				"freedom_of_speech_not_reach_fetch_enabled":                               true,
+				"standardized_nudges_misinfo":                                             true,
+				"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": false,
+				"interactive_text_enabled":                                                true,
+				"responsive_web_text_conversations_enabled":                               false,
+				"longform_notetweets_rich_text_read_enabled":                              true,
+				"longform_notetweets_inline_media_enabled":                                false,
+				"responsive_web_enhance_cards_enabled":                                    false,
+			},
+		},
+		{
+			Name:    "TweetResultByRestId",
+			QueryID: "xBtHv5-Xsk268T5ng_OGNg",
+			Method:  "GET",
+			Variables: map[string]interface{}{
+				"withCommunity":          false,
+				"includePromotedContent": false,
+				"withVoice":              false,
+			},
+			Features: usersFeatures(),
+			FieldToggles: map[string]interface{}{
+				"withArticleRichContentState": true,
+			},
+		},
+		{
+			Name:    "HomeLatestTimeline",
+			QueryID: "9EwYy8pLBOSFlEoSP2STiQ",
+			Method:  "GET",
+			Variables: map[string]interface{}{
+				"includePromotedContent":                 true,
+				"withQuickPromoteEligibilityTweetFields": true,
+				"requestContext":                         "launch",
+			},
+			Features: conversationFeatures(),
+		},
+		{
+			Name:    "HomeTimeline",
+			QueryID: "1u0Wlkw6Ru1NwBUD-pDiww",
+			Method:  "GET",
+			Variables: map[string]interface{}{
+				"includePromotedContent": true,
+				"latestControlAvailable": true,
+				"requestContext":         "launch",
+				"withCommunity":          true,
+			},
+			Features: conversationFeatures(),
+		},
+	}
+}
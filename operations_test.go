@@ -0,0 +1,191 @@
+package twitterscraper_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	twitterscraper "github.com/einys/twitter-scraper"
+)
+
+func TestOperationRegistryOverride(t *testing.T) {
+	registry := twitterscraper.NewOperationRegistry()
+	op, ok := registry.Get("UserTweets")
+	if !ok {
+		t.Fatal("Expected UserTweets to be registered")
+	}
+	if op.QueryID == "" || op.Method != "GET" {
+		t.Errorf("Unexpected default operation: %+v", op)
+	}
+
+	registry.Override(twitterscraper.Operation{
+		Name:     "UserTweets",
+		QueryID:  "newQueryID",
+		Features: map[string]interface{}{"new_feature": true},
+	})
+	updated, _ := registry.Get("UserTweets")
+	if updated.QueryID != "newQueryID" {
+		t.Errorf("Expected query ID newQueryID, got %s", updated.QueryID)
+	}
+	if updated.Features["new_feature"] != true {
+		t.Error("Expected new feature to be added")
+	}
+	if len(updated.Features) != len(op.Features)+1 {
+		t.Errorf("Expected features to be merged, got %d of %d", len(updated.Features), len(op.Features)+1)
+	}
+	if updated.Method != op.Method {
+		t.Errorf("Expected method %s to be kept, got %s", op.Method, updated.Method)
+	}
+
+	updated.Features["mutated"] = true
+	if again, _ := registry.Get("UserTweets"); again.Features["mutated"] != nil {
+		t.Error("Expected Get to return a copy")
+	}
+}
+
+func TestOperationRegistryLoad(t *testing.T) {
+	dir := t.TempDir()
+	jsonFile := filepath.Join(dir, "operations.json")
+	yamlFile := filepath.Join(dir, "operations.yaml")
+	if err := os.WriteFile(jsonFile, []byte(`{"UserTweets": {"query_id": "jsonID", "variables": {"count": 5}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(yamlFile, []byte("SearchTimeline:\n  query_id: yamlID\n  method: post\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	registry := twitterscraper.NewOperationRegistry()
+	for _, file := range []string{jsonFile, yamlFile} {
+		if err := registry.LoadFile(file); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if op, _ := registry.Get("UserTweets"); op.QueryID != "jsonID" || op.Variables["count"] != float64(5) {
+		t.Errorf("Unexpected UserTweets after LoadFile: %+v", op)
+	}
+	if op, _ := registry.Get("SearchTimeline"); op.QueryID != "yamlID" || op.Method != "POST" {
+		t.Errorf("Unexpected SearchTimeline after LoadFile: %+v", op)
+	}
+
+	if err := registry.LoadFile(filepath.Join(dir, "operations.txt")); err == nil {
+		t.Error("Expected error for unsupported file")
+	}
+	if err := registry.LoadJSON(strings.NewReader(`not json`)); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+}
+
+func TestOperationOverrideRequest(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/UserByScreenName") {
+			http.NotFound(w, r)
+			return
+		}
+		if !strings.HasSuffix(r.URL.Path, "/overriddenID/UserByScreenName") {
+			t.Errorf("Expected overridden query ID in path, got %s", r.URL.Path)
+		}
+		var features map[string]interface{}
+		if err := json.Unmarshal([]byte(r.URL.Query().Get("features")), &features); err != nil {
+			t.Fatal(err)
+		}
+		if features["overridden_feature"] != true {
+			t.Errorf("Expected overridden feature in query, got %v", features)
+		}
+		fmt.Fprint(w, `{"data":{"user":{"result":{"rest_id":"783214","legacy":{"screen_name":"X","name":"X"}}}}}`)
+	})
+	scraper := twitterscraper.New().WithBaseURL(server.URL)
+	scraper.Operations().Override(twitterscraper.Operation{
+		Name:     "UserByScreenName",
+		QueryID:  "overriddenID",
+		Features: map[string]interface{}{"overridden_feature": true},
+	})
+	if _, err := scraper.GetProfile("X"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOperationPostBody(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/verify_credentials.json"):
+			fmt.Fprint(w, `{}`)
+		case strings.HasSuffix(r.URL.Path, "/SearchTimeline"):
+			if r.Method != http.MethodPost {
+				t.Errorf("Expected POST, got %s", r.Method)
+			}
+			body, _ := io.ReadAll(r.Body)
+			var payload struct {
+				QueryID   string                 `json:"queryId"`
+				Variables map[string]interface{} `json:"variables"`
+			}
+			if err := json.Unmarshal(body, &payload); err != nil {
+				t.Fatal(err)
+			}
+			if payload.QueryID != "postID" || payload.Variables["rawQuery"] != "twitter" {
+				t.Errorf("Unexpected body: %s", body)
+			}
+			fmt.Fprint(w, `{}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	scraper := twitterscraper.New().WithBaseURL(server.URL)
+	scraper.SetAuthToken(twitterscraper.AuthToken{Token: "auth", CSRFToken: "ct0"})
+	if !scraper.IsLoggedIn() {
+		t.Fatal("Expected scraper to be logged in")
+	}
+	scraper.Operations().Override(twitterscraper.Operation{Name: "SearchTimeline", QueryID: "postID", Method: "POST"})
+	if _, _, err := scraper.FetchSearchTweets("twitter", 20, ""); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOperationPathOfTweetDetail(t *testing.T) {
+	var paths []string
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/verify_credentials.json"):
+			fmt.Fprint(w, `{}`)
+		case strings.HasSuffix(r.URL.Path, "/TweetDetail"):
+			paths = append(paths, r.URL.Path)
+			query := r.URL.Query()
+			if !strings.Contains(query.Get("variables"), `"withV2Timeline":true`) {
+				t.Errorf("Unexpected query %v", query)
+			}
+			fmt.Fprint(w, `{"data":{}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	scraper := twitterscraper.New().WithBaseURL(server.URL)
+	if !scraper.IsLoggedIn() {
+		t.Fatal("Expected to be logged in")
+	}
+	scraper.GetTweet("1")
+	scraper.Operations().SetQueryID("TweetDetail", "rotatedID")
+	scraper.GetTweet("1")
+	if len(paths) != 2 || !strings.HasSuffix(paths[0], "/VWFGPVAGkZMGRKGe3GFFnA/TweetDetail") || !strings.HasSuffix(paths[1], "/rotatedID/TweetDetail") {
+		t.Errorf("Expected query ID of TweetDetail to apply to GetTweet, got %q", paths)
+	}
+	if op, _ := scraper.Operations().Get("TweetDetailSingle"); op.Variables["rankingMode"] != nil {
+		t.Errorf("Expected variables of GetTweet to be kept, got %v", op.Variables)
+	}
+}
+
+func TestOperationSharedPathApply(t *testing.T) {
+	registry := twitterscraper.NewOperationRegistry()
+	discovered := &twitterscraper.DiscoveredOperations{Operations: map[string]twitterscraper.Operation{
+		"TweetDetail": {Name: "TweetDetail", QueryID: "discoveredID", Method: "GET"},
+	}}
+	discovered.Apply(registry)
+	for _, name := range []string{"TweetDetail", "TweetDetailSingle"} {
+		if op, _ := registry.Get(name); op.QueryID != "discoveredID" || !strings.HasSuffix(op.URL(), "/discoveredID/TweetDetail") {
+			t.Errorf("Expected discovered query ID of %s, got %s", name, op.URL())
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package twitterscraper
+
+import "context"
+
+type requestOptionsKey struct{}
+
+// requestOptions override scraper settings for requests made with a context.
+type requestOptions struct {
+	searchMode     *SearchMode
+	includeReplies *bool
+	bearerToken    string
+	// asLoggedIn sends requests without guest token, used to verify a session
+	asLoggedIn bool
+	// idempotent marks POST requests safe to retry on connection errors and 5xx, like upload steps
+	idempotent bool
+}
+
+func optionsFromContext(ctx context.Context) requestOptions {
+	if opts, ok := ctx.Value(requestOptionsKey{}).(requestOptions); ok {
+		return opts
+	}
+	return requestOptions{}
+}
+
+func withOptions(ctx context.Context, update func(*requestOptions)) context.Context {
+	opts := optionsFromContext(ctx)
+	update(&opts)
+	return context.WithValue(ctx, requestOptionsKey{}, opts)
+}
+
+// ContextWithSearchMode returns a context making search methods use the mode instead of the one set with SetSearchMode.
+func ContextWithSearchMode(ctx context.Context, mode SearchMode) context.Context {
+	return withOptions(ctx, func(opts *requestOptions) { opts.searchMode = &mode })
+}
+
+// ContextWithReplies returns a context making timeline methods include replies or not, instead of the WithReplies setting.
+func ContextWithReplies(ctx context.Context, b bool) context.Context {
+	return withOptions(ctx, func(opts *requestOptions) { opts.includeReplies = &b })
+}
+
+// ContextWithBearerToken returns a context making requests authorize with the bearer token instead of the scraper one.
+func ContextWithBearerToken(ctx context.Context, token string) context.Context {
+	return withOptions(ctx, func(opts *requestOptions) { opts.bearerToken = token })
+}
+
+func contextAsLoggedIn(ctx context.Context) context.Context {
+	return withOptions(ctx, func(opts *requestOptions) { opts.asLoggedIn = true })
+}
+
+func contextIdempotent(ctx context.Context) context.Context {
+	return withOptions(ctx, func(opts *requestOptions) { opts.idempotent = true })
+}
+
+func (s *Scraper) searchModeFor(ctx context.Context) SearchMode {
+	if opts := optionsFromContext(ctx); opts.searchMode != nil {
+		return *opts.searchMode
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.searchMode
+}
+
+func (s *Scraper) includeRepliesFor(ctx context.Context) bool {
+	if opts := optionsFromContext(ctx); opts.includeReplies != nil {
+		return *opts.includeReplies
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.includeReplies
+}
+
+func (s *Scraper) bearerTokenFor(ctx context.Context) string {
+	if opts := optionsFromContext(ctx); opts.bearerToken != "" {
+		return opts.bearerToken
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bearerToken
+}
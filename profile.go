@@ -1,9 +1,9 @@
 package twitterscraper
 
 import (
+	"context"
 	"fmt"
 	"net/http"
-	"net/url"
 	"strings"
 	"sync"
 	"time"
@@ -57,43 +57,28 @@ type user struct {
 			} `json:"result"`
 		} `json:"user"`
 	} `json:"data"`
-	Errors []struct {
-		Message string `json:"message"`
-	} `json:"errors"`
+	Errors []ErrorDetail `json:"errors"`
 }
 
 // GetProfile return parsed user profile.
 func (s *Scraper) GetProfile(username string) (Profile, error) {
+	return s.GetProfileContext(context.Background(), username)
+}
+
+// GetProfileContext same as GetProfile, but accepts context for cancellation.
+func (s *Scraper) GetProfileContext(ctx context.Context, username string) (Profile, error) {
 	var jsn user
-	req, err := http.NewRequest("GET", "https://api.twitter.com/graphql/Yka-W8dz7RaEuQNkroPkYw/UserByScreenName", nil)
+	op := s.operation("UserByScreenName")
+	req, err := http.NewRequestWithContext(ctx, op.Method, op.URL(), nil)
 	if err != nil {
 		return Profile{}, err
 	}
 
 	variables := map[string]interface{}{
-		"screen_name":              username,
-		"withSafetyModeUserFields": true,
+		"screen_name": username,
 	}
 
-	features := map[string]interface{}{
-		"hidden_profile_subscriptions_enabled":                              true,
-		"rweb_tipjar_consumption_enabled":                                   true,
-		"responsive_web_graphql_exclude_directive_enabled":                  true,
-		"verified_phone_label_enabled":                                      false,
-		"subscriptions_verification_info_is_identity_verified_enabled":      true,
-		"subscriptions_verification_info_verified_since_enabled":            true,
-		"highlights_tweets_tab_ui_enabled":                                  true,
-		"responsive_web_twitter_article_notes_tab_enabled":                  true,
-		"subscriptions_feature_can_gift_premium":                            true,
-		"creator_subscriptions_tweet_preview_api_enabled":                   true,
-		"responsive_web_graphql_skip_user_profile_image_extensions_enabled": false,
-		"responsive_web_graphql_timeline_navigation_enabled":                true,
-	}
-
-	query := url.Values{}
-	query.Set("variables", mapToJSONString(variables))
-	query.Set("features", mapToJSONString(features))
-	req.URL.RawQuery = query.Encode()
+	op.encode(req, variables)
 
 	err = s.RequestAPI(req, &jsn)
 	if err != nil {
@@ -102,57 +87,47 @@ func (s *Scraper) GetProfile(username string) (Profile, error) {
 
 	if len(jsn.Errors) > 0 && jsn.Data.User.Result.RestID == "" {
 		if strings.Contains(jsn.Errors[0].Message, "Missing LdapGroup(visibility-custom-suspension)") {
-			return Profile{}, fmt.Errorf("user is suspended")
+			return Profile{}, ErrSuspended
 		}
-		return Profile{}, fmt.Errorf("%s", jsn.Errors[0].Message)
+		return Profile{}, &APIError{Errors: jsn.Errors}
 	}
 
 	if jsn.Data.User.Result.RestID == "" {
 		if jsn.Data.User.Result.Message == "User is suspended" {
-			return Profile{}, fmt.Errorf("user is suspended")
+			return Profile{}, ErrSuspended
 		}
-		return Profile{}, fmt.Errorf("user not found")
+		return Profile{}, fmt.Errorf("user %w", ErrNotFound)
 	}
 	jsn.Data.User.Result.Legacy.IDStr = jsn.Data.User.Result.RestID
 
 	if jsn.Data.User.Result.Legacy.ScreenName == "" {
-		return Profile{}, fmt.Errorf("either @%s does not exist or is private", username)
+		return Profile{}, fmt.Errorf("either @%s does not exist or is private: %w", username, ErrNotFound)
 	}
 
 	profile := parseProfile(jsn.Data.User.Result.Legacy)
 	profile.IsBlueVerified = jsn.Data.User.Result.IsBlueVerified
+	profile.URL = localizeURL(profile.URL, s.getDomain())
 	return profile, nil
 }
 
 func (s *Scraper) GetProfileByID(userID string) (Profile, error) {
+	return s.GetProfileByIDContext(context.Background(), userID)
+}
+
+// GetProfileByIDContext same as GetProfileByID, but accepts context for cancellation.
+func (s *Scraper) GetProfileByIDContext(ctx context.Context, userID string) (Profile, error) {
 	var jsn user
-	req, err := http.NewRequest("GET", "https://twitter.com/i/api/graphql/Qw77dDjp9xCpUY-AXwt-yQ/UserByRestId", nil)
+	op := s.operation("UserByRestId")
+	req, err := http.NewRequestWithContext(ctx, op.Method, op.URL(), nil)
 	if err != nil {
 		return Profile{}, err
 	}
 
 	variables := map[string]interface{}{
-		"userId":                   userID,
-		"withSafetyModeUserFields": true,
-	}
-
-	features := map[string]interface{}{
-		"hidden_profile_subscriptions_enabled":                              true,
-		"rweb_tipjar_consumption_enabled":                                   true,
-		"responsive_web_graphql_exclude_directive_enabled":                  true,
-		"verified_phone_label_enabled":                                      false,
-		"highlights_tweets_tab_ui_enabled":                                  true,
-		"responsive_web_twitter_article_notes_tab_enabled":                  true,
-		"subscriptions_feature_can_gift_premium":                            true,
-		"creator_subscriptions_tweet_preview_api_enabled":                   true,
-		"responsive_web_graphql_skip_user_profile_image_extensions_enabled": false,
-		"responsive_web_graphql_timeline_navigation_enabled":                true,
+		"userId": userID,
 	}
 
-	query := url.Values{}
-	query.Set("variables", mapToJSONString(variables))
-	query.Set("features", mapToJSONString(features))
-	req.URL.RawQuery = query.Encode()
+	op.encode(req, variables)
 
 	err = s.RequestAPI(req, &jsn)
 	if err != nil {
@@ -161,36 +136,42 @@ func (s *Scraper) GetProfileByID(userID string) (Profile, error) {
 
 	if len(jsn.Errors) > 0 && jsn.Data.User.Result.RestID == "" {
 		if strings.Contains(jsn.Errors[0].Message, "Missing LdapGroup(visibility-custom-suspension)") {
-			return Profile{}, fmt.Errorf("user is suspended")
+			return Profile{}, ErrSuspended
 		}
-		return Profile{}, fmt.Errorf("%s", jsn.Errors[0].Message)
+		return Profile{}, &APIError{Errors: jsn.Errors}
 	}
 
 	if jsn.Data.User.Result.RestID == "" {
 		if jsn.Data.User.Result.Message == "User is suspended" {
-			return Profile{}, fmt.Errorf("user is suspended")
+			return Profile{}, ErrSuspended
 		}
-		return Profile{}, fmt.Errorf("user not found")
+		return Profile{}, fmt.Errorf("user %w", ErrNotFound)
 	}
 	jsn.Data.User.Result.Legacy.IDStr = jsn.Data.User.Result.RestID
 
 	if jsn.Data.User.Result.Legacy.ScreenName == "" {
-		return Profile{}, fmt.Errorf("either @%s does not exist or is private", userID)
+		return Profile{}, fmt.Errorf("either @%s does not exist or is private: %w", userID, ErrNotFound)
 	}
 
 	profile := parseProfile(jsn.Data.User.Result.Legacy)
 	profile.IsBlueVerified = jsn.Data.User.Result.IsBlueVerified
+	profile.URL = localizeURL(profile.URL, s.getDomain())
 	return profile, nil
 }
 
 // GetUserIDByScreenName from API
 func (s *Scraper) GetUserIDByScreenName(screenName string) (string, error) {
+	return s.GetUserIDByScreenNameContext(context.Background(), screenName)
+}
+
+// GetUserIDByScreenNameContext same as GetUserIDByScreenName, but accepts context for cancellation.
+func (s *Scraper) GetUserIDByScreenNameContext(ctx context.Context, screenName string) (string, error) {
 	id, ok := cacheIDs.Load(screenName)
 	if ok {
 		return id.(string), nil
 	}
 
-	profile, err := s.GetProfile(screenName)
+	profile, err := s.GetProfileContext(ctx, screenName)
 	if err != nil {
 		return "", err
 	}
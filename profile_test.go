@@ -31,6 +31,7 @@ func TestGetProfile(t *testing.T) {
 	}
 
 	profile, err := testScraper.GetProfile("nomadic_ua")
+	skipIfNotRecorded(t, err)
 	if err != nil {
 		t.Error(err)
 	}
@@ -85,6 +86,7 @@ func TestGetProfilePrivate(t *testing.T) {
 
 	// some random private profile (found via google)
 	profile, err := testScraper.GetProfile("tomdumont")
+	skipIfNotRecorded(t, err)
 	if err != nil {
 		t.Error(err)
 	}
@@ -116,6 +118,7 @@ func TestGetProfilePrivate(t *testing.T) {
 
 func TestGetProfileErrorSuspended(t *testing.T) {
 	_, err := testScraper.GetProfile("1")
+	skipIfNotRecorded(t, err)
 	if err == nil {
 		t.Error("Expected Error, got success")
 	} else {
@@ -129,6 +132,7 @@ func TestGetProfileErrorNotFound(t *testing.T) {
 	neUser := "sample3123131"
 	expectedError := "user not found"
 	_, err := testScraper.GetProfile(neUser)
+	skipIfNotRecorded(t, err)
 	if err == nil {
 		t.Error("Expected Error, got success")
 	} else {
@@ -140,6 +144,7 @@ func TestGetProfileErrorNotFound(t *testing.T) {
 
 func TestGetProfileByID(t *testing.T) {
 	profile, err := testScraper.GetProfileByID("1221221876849995777")
+	skipIfNotRecorded(t, err)
 	if err != nil {
 		t.Error(err)
 	}
@@ -151,6 +156,7 @@ func TestGetProfileByID(t *testing.T) {
 
 func TestGetUserIDByScreenName(t *testing.T) {
 	userID, err := testScraper.GetUserIDByScreenName("X")
+	skipIfNotRecorded(t, err)
 	if err != nil {
 		t.Errorf("getUserByScreenName() error = %v", err)
 	}
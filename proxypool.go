@@ -0,0 +1,231 @@
+package twitterscraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrNoProxies returned by ProxyPool when every proxy is dead.
+var ErrNoProxies = errors.New("no alive proxies")
+
+// ProxyRotation type
+type ProxyRotation int
+
+const (
+	// ProxyRoundRobin - default rotation, use proxies in turn
+	ProxyRoundRobin ProxyRotation = iota
+	// ProxyLeastRecentlyUsed - use the proxy idle for the longest time
+	ProxyLeastRecentlyUsed
+)
+
+// DefaultProxyProbeURL requested through dead proxies to check if they are back.
+const DefaultProxyProbeURL = "https://twitter.com/robots.txt"
+
+// ProxyStats is a snapshot of a proxy state in ProxyPool.
+type ProxyStats struct {
+	Address string
+	Alive   bool
+	// Requests sent through the proxy, including failed ones
+	Requests int
+	Failures int
+	// ConsecutiveFailures since the last successful request
+	ConsecutiveFailures int
+	LastError           error
+	LastUsed            time.Time
+}
+
+type pooledProxy struct {
+	transport *http.Transport
+	stats     ProxyStats
+}
+
+// ProxyPool is an http.RoundTripper sending every request through the next alive proxy.
+// Proxies are marked dead after MaxFailures connection errors in a row or a ban response,
+// see StartProbing to bring them back.
+type ProxyPool struct {
+	// MaxFailures in a row to mark a proxy dead, 3 by default
+	MaxFailures int
+	// IsBanned reports whether the response means the proxy is banned, 407 responses by default
+	IsBanned func(resp *http.Response) bool
+	// ProbeURL requested through dead proxies, DefaultProxyProbeURL by default
+	ProbeURL string
+
+	mu       sync.Mutex
+	proxies  []*pooledProxy
+	rotation ProxyRotation
+	next     int
+}
+
+// NewProxyPool creates ProxyPool from proxy addresses in the SetProxy format.
+func NewProxyPool(proxies []string, rotation ProxyRotation) (*ProxyPool, error) {
+	if len(proxies) == 0 {
+		return nil, ErrNoProxies
+	}
+	pool := &ProxyPool{
+		MaxFailures: 3,
+		IsBanned:    isProxyBanned,
+		ProbeURL:    DefaultProxyProbeURL,
+		rotation:    rotation,
+	}
+	for _, addr := range proxies {
+		transport, err := newTransport(addr, DefaultClientTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("proxy %s: %w", addr, err)
+		}
+		pool.proxies = append(pool.proxies, &pooledProxy{
+			transport: transport,
+			stats:     ProxyStats{Address: addr, Alive: true},
+		})
+	}
+	return pool, nil
+}
+
+// WithProxyPool send requests through proxies of the pool.
+func (s *Scraper) WithProxyPool(pool *ProxyPool) *Scraper {
+	s.updateClient(func(client *http.Client) {
+		client.Transport = pool
+	})
+	s.mu.Lock()
+	s.proxy = ""
+	s.mu.Unlock()
+	return s
+}
+
+func isProxyBanned(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusProxyAuthRequired
+}
+
+// RoundTrip implements http.RoundTripper.
+func (p *ProxyPool) RoundTrip(req *http.Request) (*http.Response, error) {
+	proxy, err := p.pick()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := proxy.transport.RoundTrip(req)
+	switch {
+	case err != nil:
+		// cancelled requests say nothing about the proxy
+		if req.Context().Err() == nil {
+			p.fail(proxy, err, false)
+		}
+	case p.IsBanned != nil && p.IsBanned(resp):
+		p.fail(proxy, fmt.Errorf("proxy banned: response status %s", resp.Status), true)
+	default:
+		p.succeed(proxy)
+	}
+	return resp, err
+}
+
+// pick selects the next alive proxy according to the rotation.
+func (p *ProxyPool) pick() (*pooledProxy, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var picked *pooledProxy
+	for i := 0; i < len(p.proxies); i++ {
+		index := (p.next + i) % len(p.proxies)
+		proxy := p.proxies[index]
+		if !proxy.stats.Alive {
+			continue
+		}
+		if p.rotation == ProxyRoundRobin {
+			p.next = index + 1
+			picked = proxy
+			break
+		}
+		if picked == nil || proxy.stats.LastUsed.Before(picked.stats.LastUsed) {
+			picked = proxy
+		}
+	}
+	if picked == nil {
+		return nil, ErrNoProxies
+	}
+	picked.stats.Requests++
+	picked.stats.LastUsed = time.Now()
+	return picked, nil
+}
+
+func (p *ProxyPool) fail(proxy *pooledProxy, err error, banned bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	proxy.stats.Failures++
+	proxy.stats.ConsecutiveFailures++
+	proxy.stats.LastError = err
+	if banned || proxy.stats.ConsecutiveFailures >= p.MaxFailures {
+		proxy.stats.Alive = false
+		proxy.transport.CloseIdleConnections()
+	}
+}
+
+func (p *ProxyPool) succeed(proxy *pooledProxy) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	proxy.stats.ConsecutiveFailures = 0
+	proxy.stats.Alive = true
+}
+
+// Stats returns a snapshot of every proxy state.
+func (p *ProxyPool) Stats() []ProxyStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stats := make([]ProxyStats, 0, len(p.proxies))
+	for _, proxy := range p.proxies {
+		stats = append(stats, proxy.stats)
+	}
+	return stats
+}
+
+// Probe requests ProbeURL through every dead proxy and brings back the ones that answer.
+func (p *ProxyPool) Probe(ctx context.Context) {
+	p.mu.Lock()
+	var dead []*pooledProxy
+	for _, proxy := range p.proxies {
+		if !proxy.stats.Alive {
+			dead = append(dead, proxy)
+		}
+	}
+	probeURL := p.ProbeURL
+	p.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, proxy := range dead {
+		wg.Add(1)
+		go func(proxy *pooledProxy) {
+			defer wg.Done()
+			req, err := http.NewRequestWithContext(ctx, "GET", probeURL, nil)
+			if err != nil {
+				return
+			}
+			resp, err := proxy.transport.RoundTrip(req)
+			if err != nil {
+				return
+			}
+			resp.Body.Close()
+			if p.IsBanned == nil || !p.IsBanned(resp) {
+				p.succeed(proxy)
+			}
+		}(proxy)
+	}
+	wg.Wait()
+}
+
+// StartProbing probes dead proxies every interval until ctx is done.
+func (p *ProxyPool) StartProbing(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				p.Probe(ctx)
+			}
+		}
+	}()
+}
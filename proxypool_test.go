@@ -0,0 +1,159 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	twitterscraper "github.com/einys/twitter-scraper"
+)
+
+// newTestProxy starts an http proxy answering every request itself.
+func newTestProxy(t *testing.T, requests *int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		if r.URL.Path == "/1.1/guest/activate.json" {
+			fmt.Fprint(w, `{"guest_token":"1234567890"}`)
+			return
+		}
+		fmt.Fprint(w, `{"data":{"user":{"result":{"rest_id":"783214","legacy":{"screen_name":"X","name":"X"}}}}}`)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestProxyPoolRoundRobin(t *testing.T) {
+	var first, second int32
+	pool, err := twitterscraper.NewProxyPool([]string{newTestProxy(t, &first).URL, newTestProxy(t, &second).URL}, twitterscraper.ProxyRoundRobin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scraper := twitterscraper.New().WithBaseURL("http://twitter.test").WithProxyPool(pool)
+
+	for i := 0; i < 2; i++ {
+		if _, err := scraper.GetProfile("X"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// guest token and 2 profiles
+	if first != 2 || second != 1 {
+		t.Errorf("Expected requests to alternate between proxies, got %d and %d", first, second)
+	}
+	for _, stats := range pool.Stats() {
+		if !stats.Alive || stats.Failures != 0 {
+			t.Errorf("Unexpected stats %#v", stats)
+		}
+	}
+}
+
+func TestProxyPoolLeastRecentlyUsed(t *testing.T) {
+	var first, second, third int32
+	proxies := []string{newTestProxy(t, &first).URL, newTestProxy(t, &second).URL, newTestProxy(t, &third).URL}
+	pool, err := twitterscraper.NewProxyPool(proxies, twitterscraper.ProxyLeastRecentlyUsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: pool}
+
+	for i := 0; i < 6; i++ {
+		resp, err := client.Get("http://twitter.test/")
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+	if first != 2 || second != 2 || third != 2 {
+		t.Errorf("Expected requests to spread evenly, got %d, %d and %d", first, second, third)
+	}
+}
+
+func TestProxyPoolDeadProxy(t *testing.T) {
+	var alive int32
+	dead := httptest.NewServer(http.NotFoundHandler())
+	deadURL := dead.URL
+	dead.Close()
+
+	pool, err := twitterscraper.NewProxyPool([]string{deadURL, newTestProxy(t, &alive).URL}, twitterscraper.ProxyRoundRobin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool.MaxFailures = 1
+	client := &http.Client{Transport: pool}
+
+	if _, err := client.Get("http://twitter.test/"); err == nil {
+		t.Fatal("Expected connection error from dead proxy")
+	}
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get("http://twitter.test/")
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+	if alive != 3 {
+		t.Errorf("Expected alive proxy to serve all requests, got %d", alive)
+	}
+
+	stats := pool.Stats()
+	if stats[0].Alive || stats[0].Failures != 1 || stats[0].LastError == nil {
+		t.Errorf("Expected first proxy to be dead, got %#v", stats[0])
+	}
+	if !stats[1].Alive || stats[1].Requests != 3 {
+		t.Errorf("Unexpected alive proxy stats %#v", stats[1])
+	}
+
+	pool.ProbeURL = "http://twitter.test/"
+	pool.Probe(context.Background())
+	if pool.Stats()[0].Alive {
+		t.Error("Expected proxy to stay dead while it refuses connections")
+	}
+}
+
+func TestProxyPoolBanned(t *testing.T) {
+	banned := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusProxyAuthRequired)
+	}))
+	t.Cleanup(banned.Close)
+
+	pool, err := twitterscraper.NewProxyPool([]string{banned.URL}, twitterscraper.ProxyRoundRobin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: pool}
+
+	resp, err := client.Get("http://twitter.test/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if _, err := client.Get("http://twitter.test/"); !errors.Is(err, twitterscraper.ErrNoProxies) {
+		t.Errorf("Expected ErrNoProxies after ban, got %v", err)
+	}
+
+	pool.ProbeURL = "http://twitter.test/"
+	pool.IsBanned = func(resp *http.Response) bool { return false }
+	pool.Probe(context.Background())
+	if !pool.Stats()[0].Alive {
+		t.Error("Expected probe to bring proxy back")
+	}
+}
+
+func TestNewProxyPoolInvalid(t *testing.T) {
+	if _, err := twitterscraper.NewProxyPool([]string{"ftp://localhost:21"}, twitterscraper.ProxyRoundRobin); err == nil {
+		t.Error("Expected error for unsupported proxy")
+	}
+	if _, err := twitterscraper.NewProxyPool(nil, twitterscraper.ProxyRoundRobin); !errors.Is(err, twitterscraper.ErrNoProxies) {
+		t.Errorf("Expected ErrNoProxies, got %v", err)
+	}
+}
+
+func TestSetProxyInvalidSocks5(t *testing.T) {
+	scraper := twitterscraper.New()
+	if err := scraper.SetProxy("socks5://%zz"); err == nil {
+		t.Error("Expected error for invalid socks5 address")
+	}
+}
@@ -0,0 +1,155 @@
+package twitterscraper
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimitMode type
+type RateLimitMode int
+
+const (
+	// RateLimitIgnore - default mode, send requests even if the budget is exhausted
+	RateLimitIgnore RateLimitMode = iota
+	// RateLimitWait - block until the limit resets
+	RateLimitWait
+	// RateLimitFail - return *RateLimitError without sending the request
+	RateLimitFail
+)
+
+// RateLimit is the request budget of an endpoint reported by X-Rate-Limit-* headers.
+type RateLimit struct {
+	// Endpoint is a GraphQL operation name, like `UserTweets`, or a REST path, like `1.1/account/settings.json`
+	Endpoint  string
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// Exhausted reports whether no requests are left until Reset.
+func (l RateLimit) Exhausted() bool {
+	return l.Remaining <= 0 && time.Now().Before(l.Reset)
+}
+
+type rateLimiter struct {
+	mu     sync.Mutex
+	limits map[string]RateLimit
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{limits: make(map[string]RateLimit)}
+}
+
+func (r *rateLimiter) update(endpoint string, header http.Header) {
+	limit, ok := parseRateLimit(header)
+	if !ok {
+		return
+	}
+	limit.Endpoint = endpoint
+	r.mu.Lock()
+	r.limits[endpoint] = limit
+	r.mu.Unlock()
+}
+
+func (r *rateLimiter) get(endpoint string) (RateLimit, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	limit, ok := r.limits[endpoint]
+	return limit, ok
+}
+
+func (r *rateLimiter) all() []RateLimit {
+	r.mu.Lock()
+	limits := make([]RateLimit, 0, len(r.limits))
+	for _, limit := range r.limits {
+		limits = append(limits, limit)
+	}
+	r.mu.Unlock()
+	sort.Slice(limits, func(i, j int) bool { return limits[i].Endpoint < limits[j].Endpoint })
+	return limits
+}
+
+func (r *rateLimiter) reset() {
+	r.mu.Lock()
+	r.limits = make(map[string]RateLimit)
+	r.mu.Unlock()
+}
+
+// parseRateLimit reads X-Rate-Limit-Limit, X-Rate-Limit-Remaining and X-Rate-Limit-Reset headers.
+func parseRateLimit(header http.Header) (RateLimit, bool) {
+	limit, err := strconv.Atoi(header.Get("X-Rate-Limit-Limit"))
+	if err != nil {
+		return RateLimit{}, false
+	}
+	remaining, err := strconv.Atoi(header.Get("X-Rate-Limit-Remaining"))
+	if err != nil {
+		return RateLimit{}, false
+	}
+	return RateLimit{
+		Limit:     limit,
+		Remaining: remaining,
+		Reset:     parseRateLimitReset(header),
+	}, true
+}
+
+// parseRateLimitReset returns the time from X-Rate-Limit-Reset header, zero if absent.
+func parseRateLimitReset(header http.Header) time.Time {
+	reset, err := strconv.ParseInt(header.Get("X-Rate-Limit-Reset"), 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(reset, 0)
+}
+
+// endpointName returns the key rate limits are tracked by.
+func endpointName(u *url.URL) string {
+	if operation := graphQLOperation(u.Path); operation != "" {
+		return operation
+	}
+	return strings.Trim(u.Path, "/")
+}
+
+// WithRateLimitMode set what to do when the budget of an endpoint is exhausted.
+func (s *Scraper) WithRateLimitMode(mode RateLimitMode) *Scraper {
+	s.mu.Lock()
+	s.rateLimitMode = mode
+	s.mu.Unlock()
+	return s
+}
+
+// RateLimit returns the last known budget of an endpoint.
+// Endpoint is a GraphQL operation name, like `UserTweets`, or a REST path, like `1.1/account/settings.json`.
+func (s *Scraper) RateLimit(endpoint string) (RateLimit, bool) {
+	return s.rateLimits.get(endpoint)
+}
+
+// RateLimits returns the last known budget of all requested endpoints.
+func (s *Scraper) RateLimits() []RateLimit {
+	return s.rateLimits.all()
+}
+
+// checkRateLimit waits for the reset or fails, depending on the mode, when the endpoint budget is exhausted.
+func (s *Scraper) checkRateLimit(ctx context.Context, endpoint string) error {
+	s.mu.RLock()
+	mode := s.rateLimitMode
+	s.mu.RUnlock()
+	if mode == RateLimitIgnore {
+		return nil
+	}
+	limit, ok := s.rateLimits.get(endpoint)
+	if !ok || !limit.Exhausted() {
+		return nil
+	}
+	if mode == RateLimitFail {
+		return &RateLimitError{Endpoint: endpoint, Reset: limit.Reset}
+	}
+	wait := time.Until(limit.Reset)
+	s.getMetrics().ObserveRateLimitWait(endpoint, wait)
+	return sleepContext(ctx, wait)
+}
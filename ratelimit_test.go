@@ -0,0 +1,112 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/einys/twitter-scraper"
+)
+
+func newRateLimitedScraper(t *testing.T, reset time.Time, requests *int32) *twitterscraper.Scraper {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		w.Header().Set("X-Rate-Limit-Limit", "150")
+		w.Header().Set("X-Rate-Limit-Remaining", "0")
+		w.Header().Set("X-Rate-Limit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		fmt.Fprint(w, `{"data":{"user":{"result":{"rest_id":"783214","legacy":{"screen_name":"X","name":"X"}}}}}`)
+	})
+
+	scraper := twitterscraper.New().WithBaseURL(server.URL)
+	scraper.SetAuthToken(twitterscraper.AuthToken{Token: "token", CSRFToken: "csrf"})
+	if !scraper.IsLoggedIn() {
+		t.Fatal("Expected IsLoggedIn() = true")
+	}
+	atomic.StoreInt32(requests, 0)
+	return scraper
+}
+
+func TestRateLimitTracking(t *testing.T) {
+	var requests int32
+	reset := time.Now().Add(time.Hour)
+	scraper := newRateLimitedScraper(t, reset, &requests)
+
+	if _, err := scraper.GetProfile("X"); err != nil {
+		t.Fatal(err)
+	}
+
+	limit, ok := scraper.RateLimit("UserByScreenName")
+	if !ok {
+		t.Fatal("Expected rate limit for UserByScreenName")
+	}
+	if limit.Limit != 150 || limit.Remaining != 0 || limit.Reset.Unix() != reset.Unix() {
+		t.Errorf("Unexpected rate limit %#v", limit)
+	}
+	if !limit.Exhausted() {
+		t.Error("Expected rate limit to be exhausted")
+	}
+	if len(scraper.RateLimits()) != 2 {
+		t.Errorf("Expected 2 tracked endpoints, got %#v", scraper.RateLimits())
+	}
+
+	// default mode keeps sending requests
+	if _, err := scraper.GetProfile("X"); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 requests, got %d", requests)
+	}
+}
+
+func TestRateLimitFail(t *testing.T) {
+	var requests int32
+	reset := time.Now().Add(time.Hour)
+	scraper := newRateLimitedScraper(t, reset, &requests).WithRateLimitMode(twitterscraper.RateLimitFail)
+
+	if _, err := scraper.GetProfile("X"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := scraper.GetProfile("X")
+	var rateLimitErr *twitterscraper.RateLimitError
+	if !errors.As(err, &rateLimitErr) || !errors.Is(err, twitterscraper.ErrRateLimited) {
+		t.Fatalf("Expected *RateLimitError, got %v", err)
+	}
+	if rateLimitErr.Endpoint != "UserByScreenName" || rateLimitErr.Reset.Unix() != reset.Unix() {
+		t.Errorf("Unexpected error %#v", rateLimitErr)
+	}
+	if requests != 1 {
+		t.Errorf("Expected exhausted endpoint not to be requested, got %d requests", requests)
+	}
+}
+
+func TestRateLimitWait(t *testing.T) {
+	var requests int32
+	reset := time.Now().Add(2 * time.Second).Truncate(time.Second)
+	scraper := newRateLimitedScraper(t, reset, &requests).WithRateLimitMode(twitterscraper.RateLimitWait)
+
+	if _, err := scraper.GetProfile("X"); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := scraper.GetProfileContext(ctx, "X"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected wait to be interrupted by context, got %v", err)
+	}
+
+	if _, err := scraper.GetProfile("X"); err != nil {
+		t.Fatal(err)
+	}
+	if time.Now().Before(reset) {
+		t.Error("Expected request to wait until reset")
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 requests, got %d", requests)
+	}
+}
@@ -12,6 +12,7 @@ func TestGetReplies(t *testing.T) {
 	tweetId := "1697304622749086011"
 
 	tweets, cursors, err := testScraper.GetTweetReplies(tweetId, "")
+	skipIfNotRecorded(t, err)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,182 @@
+package twitterscraper
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how RequestAPI retries failed requests.
+type RetryPolicy struct {
+	// MaxAttempts including the first one, retries are disabled if less than 2
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry, doubled for every next one
+	MinBackoff time.Duration
+	// MaxBackoff caps every delay. If Retry-After or rate limit reset asks to wait longer, the error is returned without retry.
+	MaxBackoff time.Duration
+	// Jitter randomly shortens a delay by up to this fraction, from 0 to 1
+	Jitter float64
+	// RetryStatuses are response statuses worth retrying, connection errors are always retried
+	RetryStatuses []int
+	// RetryNonIdempotent retries POST and other requests besides GET and HEAD on connection errors and 5xx,
+	// which may apply them twice. 429 responses are retried for every method,
+	// media upload APPEND and FINALIZE steps are safe to repeat and retried anyway.
+	RetryNonIdempotent bool
+	// OnRetry is called before waiting for every retry
+	OnRetry func(RetryEvent)
+}
+
+// RetryEvent describes a failed attempt that is going to be retried.
+type RetryEvent struct {
+	Request *http.Request
+	// Attempt is the number of the failed attempt, starting from 1
+	Attempt int
+	// StatusCode of the failed response, 0 for connection errors
+	StatusCode int
+	// Err is the connection error, nil for failed responses
+	Err error
+	// Delay before the next attempt
+	Delay time.Duration
+}
+
+// DefaultRetryPolicy retries connection errors and 5xx responses of GET and HEAD requests
+// and 429 responses of any request up to 3 times.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	MinBackoff:  time.Second,
+	MaxBackoff:  time.Minute,
+	Jitter:      0.5,
+	RetryStatuses: []int{
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	},
+}
+
+// WithRetry enable retries of failed requests, e.g. `WithRetry(twitterscraper.DefaultRetryPolicy)`
+func (s *Scraper) WithRetry(policy RetryPolicy) *Scraper {
+	s.mu.Lock()
+	s.retryPolicy = policy
+	s.mu.Unlock()
+	return s
+}
+
+// doWithRetry sends the request and retries it according to the retry policy.
+// The last response is returned as is, so a failed status is still handled by the caller.
+func (s *Scraper) doWithRetry(req *http.Request) (*http.Response, error) {
+	s.mu.RLock()
+	policy := s.retryPolicy
+	client := s.client
+	s.mu.RUnlock()
+	if policy.MaxAttempts < 2 {
+		return client.Do(req)
+	}
+
+	if req.Body != nil && req.GetBody == nil {
+		body, err := readRequestBody(req)
+		if err != nil {
+			return nil, err
+		}
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := client.Do(req)
+		if attempt >= policy.MaxAttempts || req.Context().Err() != nil {
+			return resp, err
+		}
+
+		event := RetryEvent{Request: req, Attempt: attempt, Err: err}
+		delay := policy.backoff(attempt)
+		if err != nil && !policy.retryRequest(req) {
+			return resp, err
+		}
+		if err == nil {
+			if !policy.retryable(resp.StatusCode) ||
+				(resp.StatusCode != http.StatusTooManyRequests && !policy.retryRequest(req)) {
+				return resp, nil
+			}
+			event.StatusCode = resp.StatusCode
+			if wait, ok := retryAfter(resp); ok {
+				if policy.MaxBackoff > 0 && wait > policy.MaxBackoff {
+					return resp, nil
+				}
+				if wait > delay {
+					delay = wait
+				}
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		event.Delay = delay
+
+		s.getMetrics().ObserveRetry(endpointName(req.URL))
+		if policy.OnRetry != nil {
+			policy.OnRetry(event)
+		}
+		if err := sleepContext(req.Context(), delay); err != nil {
+			return nil, err
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+	}
+}
+
+// retryRequest reports whether failures other than 429 are retried for the request.
+func (p RetryPolicy) retryRequest(req *http.Request) bool {
+	return req.Method == http.MethodGet || req.Method == http.MethodHead || p.RetryNonIdempotent ||
+		optionsFromContext(req.Context()).idempotent
+}
+
+func (p RetryPolicy) retryable(status int) bool {
+	for _, s := range p.RetryStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns exponential delay with jitter before the retry of the attempt.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := time.Duration(float64(p.MinBackoff) * math.Pow(2, float64(attempt-1)))
+	if p.MaxBackoff > 0 && (delay > p.MaxBackoff || delay <= 0) {
+		delay = p.MaxBackoff
+	}
+	if p.Jitter > 0 {
+		delay -= time.Duration(rand.Float64() * math.Min(p.Jitter, 1) * float64(delay))
+	}
+	return delay
+}
+
+// retryAfter returns the wait asked by Retry-After header, or by X-Rate-Limit-Reset for 429 responses.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if value := resp.Header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if date, err := http.ParseTime(value); err == nil {
+			return time.Until(date), true
+		}
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if reset := parseRateLimitReset(resp.Header); !reset.IsZero() {
+			return time.Until(reset), true
+		}
+	}
+	return 0, false
+}
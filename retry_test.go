@@ -0,0 +1,185 @@
+package twitterscraper_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/einys/twitter-scraper"
+)
+
+var testRetryPolicy = twitterscraper.RetryPolicy{
+	MaxAttempts:   3,
+	MinBackoff:    time.Millisecond,
+	MaxBackoff:    10 * time.Millisecond,
+	Jitter:        0.5,
+	RetryStatuses: []int{http.StatusTooManyRequests, http.StatusServiceUnavailable},
+}
+
+func TestRetry(t *testing.T) {
+	var requests int
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"data":{"user":{"result":{"rest_id":"783214","legacy":{"screen_name":"X","name":"X"}}}}}`)
+	})
+
+	var events []twitterscraper.RetryEvent
+	policy := testRetryPolicy
+	policy.OnRetry = func(event twitterscraper.RetryEvent) {
+		events = append(events, event)
+	}
+	scraper := twitterscraper.New().WithBaseURL(server.URL).WithRetry(policy)
+
+	profile, err := scraper.GetProfile("X")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.UserID != "783214" {
+		t.Errorf("Expected profile after retries, got %#v", profile)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 retries, got %d", len(events))
+	}
+	for i, event := range events {
+		if event.Attempt != i+1 || event.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("Unexpected retry event %#v", event)
+		}
+		if event.Delay > policy.MaxBackoff {
+			t.Errorf("Expected delay to be capped, got %v", event.Delay)
+		}
+	}
+}
+
+func TestRetryExhausted(t *testing.T) {
+	var requests int
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	scraper := twitterscraper.New().WithBaseURL(server.URL).WithRetry(testRetryPolicy)
+
+	_, err := scraper.GetProfile("X")
+	var apiErr *twitterscraper.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected *APIError with status 503, got %v", err)
+	}
+	if requests != testRetryPolicy.MaxAttempts {
+		t.Errorf("Expected %d requests, got %d", testRetryPolicy.MaxAttempts, requests)
+	}
+}
+
+func TestRetryRequestBody(t *testing.T) {
+	var bodies []string
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"data":{"delete_tweet":{"tweet_results":{}}}}`)
+	})
+	policy := testRetryPolicy
+	policy.RetryNonIdempotent = true
+	scraper := twitterscraper.New().WithBaseURL(server.URL).WithRetry(policy)
+
+	if err := scraper.DeleteTweet("1846000000000000001"); err != nil {
+		t.Fatal(err)
+	}
+	if len(bodies) != 2 || bodies[0] != bodies[1] || !strings.Contains(bodies[1], "1846000000000000001") {
+		t.Errorf("Expected the same body to be sent twice, got %q", bodies)
+	}
+}
+
+func TestRetryAfterTooLong(t *testing.T) {
+	var requests int
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Retry-After", "900")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	scraper := twitterscraper.New().WithBaseURL(server.URL).WithRetry(testRetryPolicy)
+
+	_, err := scraper.GetProfile("X")
+	if !errors.Is(err, twitterscraper.ErrRateLimited) {
+		t.Errorf("Expected ErrRateLimited, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected no retry when Retry-After exceeds MaxBackoff, got %d requests", requests)
+	}
+}
+
+func TestRetryNonIdempotent(t *testing.T) {
+	var requests int
+	status := http.StatusServiceUnavailable
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(status)
+			return
+		}
+		fmt.Fprint(w, `{"data":{"delete_tweet":{"tweet_results":{}}}}`)
+	})
+	scraper := twitterscraper.New().WithBaseURL(server.URL).WithRetry(testRetryPolicy)
+
+	var apiErr *twitterscraper.APIError
+	if err := scraper.DeleteTweet("1846000000000000001"); !errors.As(err, &apiErr) || apiErr.StatusCode != status {
+		t.Errorf("Expected *APIError with status 503, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected no retry of POST request, got %d requests", requests)
+	}
+
+	requests, status = 0, http.StatusTooManyRequests
+	if err := scraper.DeleteTweet("1846000000000000001"); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Errorf("Expected POST request to be retried after 429, got %d requests", requests)
+	}
+}
+
+func TestRetryUploadAppend(t *testing.T) {
+	var appends int
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("command") {
+		case "INIT":
+			fmt.Fprint(w, `{"media_id":1,"media_id_string":"1"}`)
+		case "APPEND":
+			appends++
+			if appends == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			fmt.Fprint(w, `{}`)
+		case "FINALIZE":
+			fmt.Fprint(w, `{"media_id":1,"media_id_string":"1"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	scraper := twitterscraper.New().WithBaseURL(server.URL).WithRetry(testRetryPolicy)
+
+	f, err := os.CreateTemp("", "upload*.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	f.Write([]byte("\x89PNG\r\n\x1a\n"))
+	f.Close()
+	if _, err := scraper.UploadMedia(f.Name()); err != nil {
+		t.Fatal(err)
+	}
+	if appends != 2 {
+		t.Errorf("Expected APPEND to be retried once, got %d requests", appends)
+	}
+}
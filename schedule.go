@@ -1,11 +1,8 @@
 package twitterscraper
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"errors"
-	"io"
-	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -129,18 +126,18 @@ func (timeline *scheduleTweets) parseTweets() []*ScheduledTweet {
 
 // FetchScheduledTweets gets scheduled tweets via the Twitter frontend GraphQL API.
 func (s *Scraper) FetchScheduledTweets() ([]*ScheduledTweet, error) {
-	req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/ITtjAzvlZni2wWXwf295Qg/FetchScheduledTweets")
+	return s.FetchScheduledTweetsContext(context.Background())
+}
+
+// FetchScheduledTweetsContext same as FetchScheduledTweets, but accepts context for cancellation.
+func (s *Scraper) FetchScheduledTweetsContext(ctx context.Context) ([]*ScheduledTweet, error) {
+	op := s.operation("FetchScheduledTweets")
+	req, err := s.newRequest(ctx, op.Method, op.URL())
 	if err != nil {
 		return nil, err
 	}
 
-	variables := map[string]interface{}{
-		"ascending": true,
-	}
-
-	query := url.Values{}
-	query.Set("variables", mapToJSONString(variables))
-	req.URL.RawQuery = query.Encode()
+	op.encode(req, nil)
 
 	var timeline scheduleTweets
 	err = s.RequestAPI(req, &timeline)
@@ -154,24 +151,22 @@ func (s *Scraper) FetchScheduledTweets() ([]*ScheduledTweet, error) {
 
 // DeleteScheduledTweet removes tweet from scheduled.
 func (s *Scraper) DeleteScheduledTweet(id string) error {
-	req, err := s.newRequest("POST", "https://twitter.com/i/api/graphql/CTOVqej0JBXAZSwkp1US0g/DeleteScheduledTweet")
+	return s.DeleteScheduledTweetContext(context.Background(), id)
+}
+
+// DeleteScheduledTweetContext same as DeleteScheduledTweet, but accepts context for cancellation.
+func (s *Scraper) DeleteScheduledTweetContext(ctx context.Context, id string) error {
+	op := s.operation("DeleteScheduledTweet")
+	req, err := s.newRequest(ctx, op.Method, op.URL())
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("content-type", "application/json")
-
 	variables := map[string]interface{}{
 		"scheduled_tweet_id": id,
 	}
 
-	body := map[string]interface{}{
-		"variables": variables,
-		"queryId":   "CTOVqej0JBXAZSwkp1US0g",
-	}
-
-	b, _ := json.Marshal(body)
-	req.Body = io.NopCloser(bytes.NewReader(b))
+	op.encode(req, variables)
 
 	var response struct {
 		Data struct {
@@ -193,17 +188,21 @@ func (s *Scraper) DeleteScheduledTweet(id string) error {
 
 // CreateScheduledTweet schedule new tweet.
 func (s *Scraper) CreateScheduledTweet(schedule TweetSchedule) (string, error) {
+	return s.CreateScheduledTweetContext(context.Background(), schedule)
+}
+
+// CreateScheduledTweetContext same as CreateScheduledTweet, but accepts context for cancellation.
+func (s *Scraper) CreateScheduledTweetContext(ctx context.Context, schedule TweetSchedule) (string, error) {
 	if schedule.Date.Unix() <= time.Now().Unix() {
 		return "", errors.New("date can't be in past")
 	}
 
-	req, err := s.newRequest("POST", "https://twitter.com/i/api/graphql/LCVzRQGxOaGnOnYH01NQXg/CreateScheduledTweet")
+	op := s.operation("CreateScheduledTweet")
+	req, err := s.newRequest(ctx, op.Method, op.URL())
 	if err != nil {
 		return "", err
 	}
 
-	req.Header.Set("content-type", "application/json")
-
 	post_tweet_request := map[string]interface{}{
 		"auto_populate_reply_metadata": false,
 		"status":                       schedule.Text,
@@ -226,13 +225,7 @@ func (s *Scraper) CreateScheduledTweet(schedule TweetSchedule) (string, error) {
 		"execute_at":         schedule.Date.Unix(),
 	}
 
-	body := map[string]interface{}{
-		"variables": variables,
-		"queryId":   "LCVzRQGxOaGnOnYH01NQXg",
-	}
-
-	b, _ := json.Marshal(body)
-	req.Body = io.NopCloser(bytes.NewReader(b))
+	op.encode(req, variables)
 
 	var response struct {
 		Data struct {
@@ -14,6 +14,7 @@ func TestFetchScheduledTweets(t *testing.T) {
 		t.Skip("Skipping test due to environment variable")
 	}
 	scheduled, err := testScraper.FetchScheduledTweets()
+	skipIfNotRecorded(t, err)
 	if err != nil {
 		t.Error(err)
 	}
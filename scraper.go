@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -14,22 +15,45 @@ import (
 	"golang.org/x/net/proxy"
 )
 
-// Scraper object
+// Scraper object, safe for concurrent use
 type Scraper struct {
+	// mu guards session and settings below
+	mu             sync.RWMutex
 	bearerToken    string
+	challenges     []ChallengeHandler
 	client         *http.Client
 	delay          int64
-	guestToken     string
-	guestCreatedAt time.Time
+	delayMu        sync.Mutex
+	delayUntil     time.Time
+	domain         Domain
+	dumpDir        string
+	endpoints      Endpoints
+	guestTokens    map[string]guestToken
 	includeReplies bool
+	jar            *cookieJar
+	loginDelayMin  time.Duration
+	loginDelayMax  time.Duration
+	logger         Logger
+	metrics        Metrics
+	operations     *OperationRegistry
+	transaction    *ClientTransaction
 	isLogged       bool
 	isOpenAccount  bool
 	oAuthToken     string
 	oAuthSecret    string
 	proxy          string
+	rateLimitMode  RateLimitMode
+	rateLimits     *rateLimiter
+	requestHooks   []RequestHook
+	responseHooks  []ResponseHook
+	retryPolicy    RetryPolicy
 	userAgent      string
 	searchMode     SearchMode
-	wg             sync.WaitGroup
+	sessionStore   SessionStore
+	// savedAuth holds auth cookies of the last saved session, responses refreshing them save it again
+	savedAuth map[string]string
+	// saveMu orders session saves, so an older snapshot never overwrites a newer one
+	saveMu sync.Mutex
 }
 
 // SearchMode type
@@ -52,12 +76,148 @@ const (
 const DefaultClientTimeout = 10 * time.Second
 const DefaultUserAgent = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/129.0.0.0 Safari/537.36"
 
+// guestToken is bound to the bearer token it was activated with
+type guestToken struct {
+	value     string
+	createdAt time.Time
+}
+
+// cookieJar can be cleared while requests are in flight,
+// it keeps attributes of stored cookies the standard jar doesn't return, for sessions.
+type cookieJar struct {
+	mu      sync.RWMutex
+	jar     *cookiejar.Jar
+	stored  map[storedCookieKey]http.Cookie
+	storeMu sync.Mutex
+}
+
+type storedCookieKey struct {
+	name, domain, path string
+	hostOnly           bool
+}
+
+func newCookieJar() *cookieJar {
+	jar, _ := cookiejar.New(nil)
+	return &cookieJar{jar: jar, stored: make(map[storedCookieKey]http.Cookie)}
+}
+
+// SetCookies stores cookies of twitter.com for x.com too and back, keeping sessions of both domains the same.
+func (j *cookieJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	j.jar.SetCookies(u, cookies)
+	j.store(u, cookies)
+	if sibling := siblingURL(u); sibling != nil {
+		mirrored := make([]*http.Cookie, 0, len(cookies))
+		for _, cookie := range cookies {
+			c := *cookie
+			if host, ok := siblingHost(strings.TrimPrefix(c.Domain, ".")); ok {
+				c.Domain = host
+			}
+			mirrored = append(mirrored, &c)
+		}
+		j.jar.SetCookies(sibling, mirrored)
+		j.store(sibling, mirrored)
+	}
+}
+
+// store keeps attributes of the cookies the way the jar resolves them, expired ones are removed.
+func (j *cookieJar) store(u *url.URL, cookies []*http.Cookie) {
+	now := time.Now()
+	j.storeMu.Lock()
+	defer j.storeMu.Unlock()
+	for _, cookie := range cookies {
+		key := storedCookieKey{
+			name:   cookie.Name,
+			domain: strings.ToLower(strings.TrimPrefix(cookie.Domain, ".")),
+			path:   cookie.Path,
+		}
+		if key.domain == "" {
+			key.domain = strings.ToLower(u.Hostname())
+			key.hostOnly = true
+		}
+		if key.path == "" || key.path[0] != '/' {
+			key.path = "/"
+			if i := strings.LastIndex(u.Path, "/"); i > 0 {
+				key.path = u.Path[:i]
+			}
+		}
+		c := http.Cookie{
+			Name:     cookie.Name,
+			Value:    cookie.Value,
+			Path:     key.path,
+			Expires:  cookie.Expires,
+			Secure:   cookie.Secure,
+			HttpOnly: cookie.HttpOnly,
+		}
+		if !key.hostOnly {
+			c.Domain = key.domain
+		}
+		if cookie.MaxAge > 0 {
+			c.Expires = now.Add(time.Duration(cookie.MaxAge) * time.Second)
+		}
+		if cookie.MaxAge < 0 || (!c.Expires.IsZero() && !c.Expires.After(now)) {
+			delete(j.stored, key)
+			continue
+		}
+		j.stored[key] = c
+	}
+}
+
+// storedFor returns unexpired cookies stored for the host with their attributes,
+// Domain is empty for host-only cookies.
+func (j *cookieJar) storedFor(host string) []http.Cookie {
+	host = strings.ToLower(host)
+	now := time.Now()
+	j.storeMu.Lock()
+	defer j.storeMu.Unlock()
+	var cookies []http.Cookie
+	for key, cookie := range j.stored {
+		if key.domain == host && (cookie.Expires.IsZero() || cookie.Expires.After(now)) {
+			cookies = append(cookies, cookie)
+		}
+	}
+	sort.Slice(cookies, func(i, k int) bool {
+		if cookies[i].Name != cookies[k].Name {
+			return cookies[i].Name < cookies[k].Name
+		}
+		if cookies[i].Path != cookies[k].Path {
+			return cookies[i].Path < cookies[k].Path
+		}
+		return cookies[i].Domain < cookies[k].Domain
+	})
+	return cookies
+}
+
+func (j *cookieJar) Cookies(u *url.URL) []*http.Cookie {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.jar.Cookies(u)
+}
+
+func (j *cookieJar) clear() {
+	jar, _ := cookiejar.New(nil)
+	j.mu.Lock()
+	j.jar = jar
+	j.storeMu.Lock()
+	j.stored = make(map[storedCookieKey]http.Cookie)
+	j.storeMu.Unlock()
+	j.mu.Unlock()
+}
+
 // New creates a Scraper object
 func New() *Scraper {
-	jar, _ := cookiejar.New(nil)
+	jar := newCookieJar()
 	return &Scraper{
-		bearerToken: bearerToken,
-		userAgent:   DefaultUserAgent,
+		bearerToken:   bearerToken,
+		endpoints:     DefaultEndpoints,
+		guestTokens:   make(map[string]guestToken),
+		jar:           jar,
+		loginDelayMin: 3 * time.Second,
+		loginDelayMax: 8 * time.Second,
+		operations:    NewOperationRegistry(),
+		rateLimits:    newRateLimiter(),
+		userAgent:     DefaultUserAgent,
 		client: &http.Client{
 			Jar:     jar,
 			Timeout: DefaultClientTimeout,
@@ -66,76 +226,160 @@ func New() *Scraper {
 }
 
 func (s *Scraper) setBearerToken(token string) {
+	s.mu.Lock()
 	s.bearerToken = token
-	s.guestToken = ""
+	s.mu.Unlock()
 }
 
 // IsGuestToken check if guest token not empty
 func (s *Scraper) IsGuestToken() bool {
-	return s.guestToken != ""
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.guestTokens[s.bearerToken].value != ""
 }
 
-// SetSearchMode switcher
+// SetSearchMode switcher, see ContextWithSearchMode to set it for a single call
 func (s *Scraper) SetSearchMode(mode SearchMode) *Scraper {
+	s.mu.Lock()
 	s.searchMode = mode
+	s.mu.Unlock()
 	return s
 }
 
 // WithDelay add delay between API requests (in seconds)
 func (s *Scraper) WithDelay(seconds int64) *Scraper {
+	s.delayMu.Lock()
 	s.delay = seconds
+	s.delayMu.Unlock()
 	return s
 }
 
-// WithReplies enable/disable load timeline with tweet replies
+// WithReplies enable/disable load timeline with tweet replies, see ContextWithReplies to set it for a single call
 func (s *Scraper) WithReplies(b bool) *Scraper {
+	s.mu.Lock()
 	s.includeReplies = b
+	s.mu.Unlock()
+	return s
+}
+
+// loggedIn reports whether the scraper has a session.
+func (s *Scraper) loggedIn() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.isLogged
+}
+
+// openAccount reports whether the session is an open account.
+func (s *Scraper) openAccount() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.isOpenAccount
+}
+
+// WithEndpoints override base URLs used for requests, e.g. to run against a mock server.
+// Empty fields keep the hosts of the domain set with WithDomain.
+func (s *Scraper) WithEndpoints(endpoints Endpoints) *Scraper {
+	defaults := s.getDomain().Endpoints()
+	if endpoints.Web == "" {
+		endpoints.Web = defaults.Web
+	}
+	if endpoints.API == "" {
+		endpoints.API = defaults.API
+	}
+	if endpoints.Upload == "" {
+		endpoints.Upload = defaults.Upload
+	}
+	s.mu.Lock()
+	s.endpoints = endpoints
+	s.mu.Unlock()
+	return s
+}
+
+// WithBaseURL send all requests to a single base URL, e.g. `httptest.Server.URL`
+func (s *Scraper) WithBaseURL(baseURL string) *Scraper {
+	return s.WithEndpoints(Endpoints{Web: baseURL, API: baseURL, Upload: baseURL})
+}
+
+// WithTransport replace http transport used by the client, e.g. with a Cassette
+func (s *Scraper) WithTransport(transport http.RoundTripper) *Scraper {
+	s.updateClient(func(client *http.Client) {
+		client.Transport = transport
+	})
 	return s
 }
 
 // client timeout
 func (s *Scraper) WithClientTimeout(timeout time.Duration) *Scraper {
-	s.client.Timeout = timeout
+	s.updateClient(func(client *http.Client) {
+		client.Timeout = timeout
+	})
 	return s
 }
 
+// httpClient returns the client requests are sent with.
+func (s *Scraper) httpClient() *http.Client {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.client
+}
+
+// updateClient replaces the client with a changed copy, requests in flight keep the old one.
+func (s *Scraper) updateClient(fn func(client *http.Client)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	client := *s.client
+	fn(&client)
+	s.client = &client
+}
+
 // SetProxy
 // set http proxy in the format `http://HOST:PORT`
 // set socket proxy in the format `socks5://HOST:PORT`
 func (s *Scraper) SetProxy(proxyAddr string) error {
+	transport, err := newTransport(proxyAddr, s.httpClient().Timeout)
+	if err != nil {
+		return err
+	}
+	s.updateClient(func(client *http.Client) {
+		client.Transport = transport
+	})
+	s.mu.Lock()
+	s.proxy = proxyAddr
+	s.mu.Unlock()
+	return nil
+}
+
+// newTransport creates http transport sending requests directly or through http(s) or socks5 proxy.
+func newTransport(proxyAddr string, timeout time.Duration) (*http.Transport, error) {
 	if proxyAddr == "" {
-		s.client.Transport = &http.Transport{
+		return &http.Transport{
 			TLSNextProto: make(map[string]func(authority string, c *tls.Conn) http.RoundTripper),
 			DialContext: (&net.Dialer{
-				Timeout: s.client.Timeout,
+				Timeout: timeout,
 			}).DialContext,
-		}
-		s.proxy = ""
-		return nil
+		}, nil
 	}
 	if strings.HasPrefix(proxyAddr, "http") {
 		urlproxy, err := url.Parse(proxyAddr)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		s.client.Transport = &http.Transport{
+		return &http.Transport{
 			Proxy:        http.ProxyURL(urlproxy),
 			TLSNextProto: make(map[string]func(authority string, c *tls.Conn) http.RoundTripper),
 			DialContext: (&net.Dialer{
-				Timeout: s.client.Timeout,
+				Timeout: timeout,
 			}).DialContext,
-		}
-		s.proxy = proxyAddr
-		return nil
+		}, nil
 	}
 	if strings.HasPrefix(proxyAddr, "socks5") {
 		baseDialer := &net.Dialer{
-			Timeout:   s.client.Timeout,
-			KeepAlive: s.client.Timeout,
+			Timeout:   timeout,
+			KeepAlive: timeout,
 		}
 		proxyURL, err := url.Parse(proxyAddr)
 		if err != nil {
-			panic(err)
+			return nil, err
 		}
 
 		// username password
@@ -157,26 +401,27 @@ func (s *Scraper) SetProxy(proxyAddr string) error {
 
 		dialSocksProxy, err := proxy.SOCKS5("tcp", host+":"+port, auth, baseDialer)
 		if err != nil {
-			return errors.New("error creating socks5 proxy :" + err.Error())
+			return nil, errors.New("error creating socks5 proxy :" + err.Error())
 		}
-		if contextDialer, ok := dialSocksProxy.(proxy.ContextDialer); ok {
-			dialContext := contextDialer.DialContext
-			s.client.Transport = &http.Transport{
-				DialContext: dialContext,
-			}
-		} else {
-			return errors.New("failed type assertion to DialContext")
+		contextDialer, ok := dialSocksProxy.(proxy.ContextDialer)
+		if !ok {
+			return nil, errors.New("failed type assertion to DialContext")
 		}
-		s.proxy = proxyAddr
-		return nil
+		return &http.Transport{
+			DialContext: contextDialer.DialContext,
+		}, nil
 	}
-	return errors.New("only support http(s) or socks5 protocol")
+	return nil, errors.New("only support http(s) or socks5 protocol")
 }
 
 func (s *Scraper) SetUserAgent(userAgent string) {
+	s.mu.Lock()
 	s.userAgent = userAgent
+	s.mu.Unlock()
 }
 
 func (s *Scraper) GetUserAgent() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return s.userAgent
 }
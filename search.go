@@ -2,13 +2,10 @@ package twitterscraper
 
 import (
 	"context"
-	"errors"
-	"net/url"
+	"fmt"
 	"strconv"
 )
 
-const searchURL = "https://twitter.com/i/api/graphql/nK1dw4oV3k4w5TdtcAdSww/SearchTimeline"
-
 type SearchTimeline struct {
 	Data struct {
 		SearchByRawQuery struct {
@@ -89,67 +86,39 @@ func (timeline *SearchTimeline) parseUsers() ([]*Profile, string) {
 
 // SearchTweets returns channel with tweets for a given search query
 func (s *Scraper) SearchTweets(ctx context.Context, query string, maxTweetsNbr int) <-chan *ScrappedTweetResult {
-	return getTweetTimeline(ctx, query, maxTweetsNbr, s.FetchSearchTweets)
+	return s.getTweetTimeline(ctx, query, maxTweetsNbr, s.FetchSearchTweetsContext)
 }
 
 // SearchProfiles returns channel with profiles for a given search query
 func (s *Scraper) SearchProfiles(ctx context.Context, query string, maxProfilesNbr int) <-chan *ProfileResult {
-	return getUserTimeline(ctx, query, maxProfilesNbr, s.FetchSearchProfiles)
+	return s.getUserTimeline(ctx, query, maxProfilesNbr, s.FetchSearchProfilesContext)
 }
 
 // getSearchTimeline gets results for a given search query, via the Twitter frontend API
-func (s *Scraper) getSearchTimeline(query string, maxNbr int, cursor string) (*SearchTimeline, error) {
-	if !s.isLogged {
-		return nil, errors.New("scraper is not logged in for search")
+func (s *Scraper) getSearchTimeline(ctx context.Context, query string, maxNbr int, cursor string) (*SearchTimeline, error) {
+	if !s.loggedIn() {
+		return nil, fmt.Errorf("%w for search", ErrNotLoggedIn)
 	}
 
 	if maxNbr > 50 {
 		maxNbr = 50
 	}
 
-	req, err := s.newRequest("GET", searchURL)
+	op := s.operation("SearchTimeline")
+	req, err := s.newRequest(ctx, op.Method, op.URL())
 	if err != nil {
 		return nil, err
 	}
 
 	variables := map[string]interface{}{
-		"rawQuery":    query,
-		"count":       maxNbr,
-		"querySource": "typed_query",
-		"product":     "Top",
-	}
-
-	features := map[string]interface{}{
-		"rweb_lists_timeline_redesign_enabled":                                    true,
-		"responsive_web_graphql_exclude_directive_enabled":                        true,
-		"verified_phone_label_enabled":                                            false,
-		"creator_subscriptions_tweet_preview_api_enabled":                         true,
-		"responsive_web_graphql_timeline_navigation_enabled":                      true,
-		"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
-		"tweetypie_unmention_optimization_enabled":                                true,
-		"responsive_web_edit_tweet_api_enabled":                                   true,
-		"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
-		"view_counts_everywhere_api_enabled":                                      true,
-		"longform_notetweets_consumption_enabled":                                 true,
-		"responsive_web_twitter_article_tweet_consumption_enabled":                false,
-		"tweet_awards_web_tipping_enabled":                                        false,
-		"freedom_of_speech_not_reach_fetch_enabled":                               true,
-		"standardized_nudges_misinfo":                                             true,
-		"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": true,
-		"longform_notetweets_rich_text_read_enabled":                              true,
-		"longform_notetweets_inline_media_enabled":                                true,
-		"responsive_web_media_download_video_enabled":                             false,
-		"responsive_web_enhance_cards_enabled":                                    false,
-	}
-
-	fieldToggles := map[string]interface{}{
-		"withArticleRichContentState": false,
+		"rawQuery": query,
+		"count":    maxNbr,
 	}
 
 	if cursor != "" {
 		variables["cursor"] = cursor
 	}
-	switch s.searchMode {
+	switch s.searchModeFor(ctx) {
 	case SearchLatest:
 		variables["product"] = "Latest"
 	case SearchPhotos:
@@ -160,11 +129,7 @@ func (s *Scraper) getSearchTimeline(query string, maxNbr int, cursor string) (*S
 		variables["product"] = "People"
 	}
 
-	q := url.Values{}
-	q.Set("variables", mapToJSONString(variables))
-	q.Set("features", mapToJSONString(features))
-	q.Set("fieldToggles", mapToJSONString(fieldToggles))
-	req.URL.RawQuery = q.Encode()
+	op.encode(req, variables)
 
 	var timeline SearchTimeline
 	err = s.RequestAPI(req, &timeline)
@@ -176,20 +141,30 @@ func (s *Scraper) getSearchTimeline(query string, maxNbr int, cursor string) (*S
 
 // FetchSearchTweets gets tweets for a given search query, via the Twitter frontend API
 func (s *Scraper) FetchSearchTweets(query string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
-	timeline, err := s.getSearchTimeline(query, maxTweetsNbr, cursor)
+	return s.FetchSearchTweetsContext(context.Background(), query, maxTweetsNbr, cursor)
+}
+
+// FetchSearchTweetsContext same as FetchSearchTweets, but accepts context for cancellation.
+func (s *Scraper) FetchSearchTweetsContext(ctx context.Context, query string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	timeline, err := s.getSearchTimeline(ctx, query, maxTweetsNbr, cursor)
 	if err != nil {
 		return nil, "", err
 	}
 	tweets, nextCursor := timeline.ParseTweets()
-	return tweets, nextCursor, nil
+	return s.localizeTweets(tweets), nextCursor, nil
 }
 
 // FetchSearchProfiles gets users for a given search query, via the Twitter frontend API
 func (s *Scraper) FetchSearchProfiles(query string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
-	timeline, err := s.getSearchTimeline(query, maxProfilesNbr, cursor)
+	return s.FetchSearchProfilesContext(context.Background(), query, maxProfilesNbr, cursor)
+}
+
+// FetchSearchProfilesContext same as FetchSearchProfiles, but accepts context for cancellation.
+func (s *Scraper) FetchSearchProfilesContext(ctx context.Context, query string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+	timeline, err := s.getSearchTimeline(ctx, query, maxProfilesNbr, cursor)
 	if err != nil {
 		return nil, "", err
 	}
 	users, nextCursor := timeline.parseUsers()
-	return users, nextCursor, nil
+	return s.localizeProfiles(users), nextCursor, nil
 }
@@ -17,6 +17,7 @@ func TestFetchSearchCursor(t *testing.T) {
 	nextCursor := ""
 	for tweetsNbr < maxTweetsNbr {
 		tweets, cursor, err := testScraper.FetchSearchTweets("twitter", maxTweetsNbr, nextCursor)
+		skipIfNotRecorded(t, err)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -38,6 +39,7 @@ func TestGetSearchProfiles(t *testing.T) {
 	testScraper.SetSearchMode(twitterscraper.SearchUsers)
 	for profile := range testScraper.SearchProfiles(context.Background(), "Twitter", maxProfilesNbr) {
 		if profile.Error != nil {
+			skipIfNotRecorded(t, profile.Error)
 			t.Error(profile.Error)
 		} else {
 			count++
@@ -67,6 +69,7 @@ func TestGetSearchTweets(t *testing.T) {
 	testScraper.SetSearchMode(twitterscraper.SearchLatest)
 	for tweet := range testScraper.SearchTweets(context.Background(), "twitter", maxTweetsNbr) {
 		if tweet.Error != nil {
+			skipIfNotRecorded(t, tweet.Error)
 			t.Error(tweet.Error)
 		} else {
 			count++
@@ -0,0 +1,185 @@
+package twitterscraper
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// SessionVersion of the blob produced by ExportSession, ImportSession rejects newer versions.
+const SessionVersion = 1
+
+// sessionDomains have their cookies saved in the session
+var sessionDomains = []string{"twitter.com", "x.com", "api.twitter.com", "api.x.com", "upload.twitter.com", "upload.x.com"}
+
+// Session is the state of a Scraper saved by ExportSession.
+type Session struct {
+	Version int `json:"version"`
+	// Cookies keyed by domain, like `twitter.com` or `api.twitter.com`
+	Cookies map[string][]SessionCookie `json:"cookies,omitempty"`
+	// GuestTokens activated for bearer tokens
+	GuestTokens []SessionGuestToken `json:"guest_tokens,omitempty"`
+	// BearerToken used for the session, it differs for logged in and guest sessions
+	BearerToken string `json:"bearer_token,omitempty"`
+	IsLoggedIn  bool   `json:"is_logged_in"`
+	// OpenAccount is set for sessions of LoginOpenAccount or WithOpenAccount
+	OpenAccount *OpenAccount `json:"open_account,omitempty"`
+	UserAgent   string       `json:"user_agent,omitempty"`
+	Proxy       string       `json:"proxy,omitempty"`
+}
+
+// SessionCookie is a cookie of the Session.
+type SessionCookie struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	// Domain the cookie is sent to with subdomains, empty for cookies of the host only
+	Domain   string    `json:"domain,omitempty"`
+	Path     string    `json:"path,omitempty"`
+	Expires  time.Time `json:"expires"`
+	Secure   bool      `json:"secure,omitempty"`
+	HttpOnly bool      `json:"http_only,omitempty"`
+}
+
+// SessionGuestToken is a guest token of the Session with the bearer token it was activated with.
+type SessionGuestToken struct {
+	BearerToken string    `json:"bearer_token"`
+	Token       string    `json:"token"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ExportSession save cookies, guest tokens, bearer token, OpenAccount, user agent and proxy
+// as a versioned JSON blob, restore it with ImportSession without logging in again.
+func (s *Scraper) ExportSession() ([]byte, error) {
+	return json.Marshal(s.session())
+}
+
+// ImportSession restore the state saved with ExportSession.
+// Use IsLoggedIn to check whether the restored session is still valid.
+func (s *Scraper) ImportSession(data []byte) error {
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return fmt.Errorf("decode session: %w", err)
+	}
+	return s.restoreSession(session)
+}
+
+func (s *Scraper) session() Session {
+	session := Session{
+		Version: SessionVersion,
+		Cookies: make(map[string][]SessionCookie),
+	}
+	// custom endpoints may store cookies of several domains on the same host
+	saved := make(map[string]bool)
+	for _, domain := range sessionDomains {
+		host := s.sessionURL(domain).Hostname()
+		if saved[host] {
+			continue
+		}
+		saved[host] = true
+		for _, cookie := range s.jar.storedFor(host) {
+			session.Cookies[domain] = append(session.Cookies[domain], SessionCookie{
+				Name:     cookie.Name,
+				Value:    cookie.Value,
+				Domain:   cookie.Domain,
+				Path:     cookie.Path,
+				Expires:  cookie.Expires,
+				Secure:   cookie.Secure,
+				HttpOnly: cookie.HttpOnly,
+			})
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for bearer, token := range s.guestTokens {
+		session.GuestTokens = append(session.GuestTokens, SessionGuestToken{
+			BearerToken: bearer,
+			Token:       token.value,
+			CreatedAt:   token.createdAt,
+		})
+	}
+	sort.Slice(session.GuestTokens, func(i, j int) bool {
+		return session.GuestTokens[i].BearerToken < session.GuestTokens[j].BearerToken
+	})
+	session.BearerToken = s.bearerToken
+	session.IsLoggedIn = s.isLogged
+	if s.isOpenAccount {
+		session.OpenAccount = &OpenAccount{OAuthToken: s.oAuthToken, OAuthTokenSecret: s.oAuthSecret}
+	}
+	session.UserAgent = s.userAgent
+	session.Proxy = s.proxy
+	return session
+}
+
+func (s *Scraper) restoreSession(session Session) error {
+	if session.Version < 1 || session.Version > SessionVersion {
+		return fmt.Errorf("unsupported session version %d, expected up to %d", session.Version, SessionVersion)
+	}
+	if session.Proxy != "" {
+		if err := s.SetProxy(session.Proxy); err != nil {
+			return err
+		}
+	}
+
+	s.jar.clear()
+	for domain, cookies := range session.Cookies {
+		u := s.sessionURL(domain)
+		_, known := siblingHost(u.Hostname())
+		jarCookies := make([]*http.Cookie, 0, len(cookies))
+		for _, cookie := range cookies {
+			c := &http.Cookie{
+				Name:     cookie.Name,
+				Value:    cookie.Value,
+				Domain:   cookie.Domain,
+				Path:     cookie.Path,
+				Expires:  cookie.Expires,
+				Secure:   cookie.Secure,
+				HttpOnly: cookie.HttpOnly,
+			}
+			if !known {
+				// the jar rejects cookies of twitter.com for a custom endpoint
+				c.Domain = ""
+			}
+			jarCookies = append(jarCookies, c)
+		}
+		s.jar.SetCookies(u, jarCookies)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.guestTokens = make(map[string]guestToken)
+	for _, token := range session.GuestTokens {
+		s.guestTokens[token.BearerToken] = guestToken{value: token.Token, createdAt: token.CreatedAt}
+	}
+	if session.BearerToken != "" {
+		s.bearerToken = session.BearerToken
+	}
+	s.isLogged = session.IsLoggedIn
+	s.isOpenAccount = session.OpenAccount != nil
+	s.oAuthToken, s.oAuthSecret = "", ""
+	if session.OpenAccount != nil {
+		s.oAuthToken = session.OpenAccount.OAuthToken
+		s.oAuthSecret = session.OpenAccount.OAuthTokenSecret
+	}
+	if session.UserAgent != "" {
+		s.userAgent = session.UserAgent
+	}
+	return nil
+}
+
+// sessionURL returns URL cookies of the domain are stored for, pointing to the configured endpoint
+// unless it's on twitter.com or x.com, where the jar keeps cookies of both domains.
+func (s *Scraper) sessionURL(domain string) *url.URL {
+	u := &url.URL{Scheme: "https", Host: domain, Path: "/"}
+	resolved := *u
+	if err := s.resolveURL(&resolved); err != nil {
+		return u
+	}
+	if _, known := siblingHost(resolved.Hostname()); known {
+		return u
+	}
+	return &resolved
+}
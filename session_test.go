@@ -0,0 +1,183 @@
+package twitterscraper_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	twitterscraper "github.com/einys/twitter-scraper"
+)
+
+func TestExportImportSession(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/verify_credentials.json"):
+			fmt.Fprint(w, `{}`)
+		case strings.HasSuffix(r.URL.Path, "/UserByScreenName"):
+			if cookie, err := r.Cookie("auth_token"); err != nil || cookie.Value != "auth" {
+				t.Errorf("Expected restored auth_token cookie, got %v", cookie)
+			}
+			if ua := r.Header.Get("User-Agent"); ua != "restored agent" {
+				t.Errorf("Expected restored user agent, got %s", ua)
+			}
+			fmt.Fprint(w, `{"data":{"user":{"result":{"rest_id":"783214","legacy":{"screen_name":"X","name":"X"}}}}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	scraper := twitterscraper.New().WithBaseURL(server.URL)
+	scraper.SetUserAgent("restored agent")
+	scraper.SetAuthToken(twitterscraper.AuthToken{Token: "auth", CSRFToken: "ct0"})
+	if !scraper.IsLoggedIn() {
+		t.Fatal("Expected scraper to be logged in")
+	}
+	if err := scraper.GetGuestToken(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := scraper.ExportSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var session twitterscraper.Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		t.Fatal(err)
+	}
+	if session.Version != twitterscraper.SessionVersion || !session.IsLoggedIn || len(session.GuestTokens) != 1 {
+		t.Errorf("Unexpected session: %s", data)
+	}
+	if session.GuestTokens[0].Token != "1234567890" || session.GuestTokens[0].CreatedAt.IsZero() {
+		t.Errorf("Expected guest token with creation time, got %+v", session.GuestTokens[0])
+	}
+
+	restored := twitterscraper.New().WithBaseURL(server.URL)
+	if err := restored.ImportSession(data); err != nil {
+		t.Fatal(err)
+	}
+	if !restored.IsGuestToken() {
+		t.Error("Expected guest token to be restored")
+	}
+	if _, err := restored.GetProfile("X"); err != nil {
+		t.Fatal(err)
+	}
+	again, err := restored.ExportSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, again) {
+		t.Errorf("Expected the same session after import\n%s\n%s", data, again)
+	}
+}
+
+func TestImportSessionOpenAccount(t *testing.T) {
+	scraper := twitterscraper.New()
+	scraper.WithOpenAccount(twitterscraper.OpenAccount{OAuthToken: "token", OAuthTokenSecret: "secret"})
+	if err := scraper.SetProxy("socks5://127.0.0.1:1080"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := scraper.ExportSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restored := twitterscraper.New()
+	if err := restored.ImportSession(data); err != nil {
+		t.Fatal(err)
+	}
+	again, err := restored.ExportSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var session twitterscraper.Session
+	if err := json.Unmarshal(again, &session); err != nil {
+		t.Fatal(err)
+	}
+	if session.OpenAccount == nil || session.OpenAccount.OAuthTokenSecret != "secret" || session.Proxy != "socks5://127.0.0.1:1080" {
+		t.Errorf("Unexpected restored session: %s", again)
+	}
+}
+
+func TestImportSessionVersion(t *testing.T) {
+	scraper := twitterscraper.New()
+	if err := scraper.ImportSession([]byte(fmt.Sprintf(`{"version":%d}`, twitterscraper.SessionVersion+1))); err == nil {
+		t.Error("Expected error for newer session version")
+	}
+	if err := scraper.ImportSession([]byte(`not json`)); err == nil {
+		t.Error("Expected error for invalid session")
+	}
+}
+
+func TestImportSessionDomainCookies(t *testing.T) {
+	var mu sync.Mutex
+	sent := make(map[string]string)
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if cookie, err := r.Cookie("auth_token"); err == nil {
+			mu.Lock()
+			sent[r.URL.Path] = cookie.Value
+			mu.Unlock()
+		}
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/upload.json"):
+			fmt.Fprint(w, `{"media_id":1,"media_id_string":"1"}`)
+		case strings.HasSuffix(r.URL.Path, "/UserByScreenName"):
+			fmt.Fprint(w, `{"data":{"user":{"result":{"rest_id":"783214","legacy":{"screen_name":"X","name":"X"}}}}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	recorder := &hostRecorder{target: urlMustParse(t, server.URL)}
+	scraper := twitterscraper.New().WithTransport(recorder)
+	data := `{"version":1,"cookies":{"twitter.com":[
+		{"name":"auth_token","value":"auth","domain":"twitter.com","path":"/","expires":"2100-01-01T00:00:00Z","secure":true,"http_only":true},
+		{"name":"ct0","value":"ct0","domain":"twitter.com","path":"/","expires":"2100-01-01T00:00:00Z","secure":true}
+	]},"is_logged_in":true}`
+	if err := scraper.ImportSession([]byte(data)); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.CreateTemp("", "session*.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	f.Write([]byte("\x89PNG\r\n\x1a\n"))
+	f.Close()
+	if _, err := scraper.UploadMedia(f.Name()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := scraper.WithDomain(twitterscraper.DomainX).GetProfile("X"); err != nil {
+		t.Fatal(err)
+	}
+	if hosts := recorder.recorded(); !strings.Contains(hosts, "upload.twitter.com") || !strings.Contains(hosts, "api.x.com") {
+		t.Errorf("Unexpected hosts %s", hosts)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if sent["/i/media/upload.json"] != "auth" {
+		t.Errorf("Expected auth_token sent to upload.twitter.com, got %v", sent)
+	}
+	if len(sent) < 2 {
+		t.Errorf("Expected auth_token sent to api.x.com, got %v", sent)
+	}
+
+	exported, err := scraper.ExportSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var session twitterscraper.Session
+	if err := json.Unmarshal(exported, &session); err != nil {
+		t.Fatal(err)
+	}
+	auth := session.Cookies["twitter.com"][0]
+	if auth.Name != "auth_token" || auth.Domain != "twitter.com" || auth.Path != "/" || !auth.Secure || !auth.HttpOnly || auth.Expires.Year() != 2100 {
+		t.Errorf("Expected auth_token with its attributes, got %+v", auth)
+	}
+	if len(session.Cookies["upload.twitter.com"]) != 0 {
+		t.Errorf("Expected domain cookies saved once, got %+v", session.Cookies)
+	}
+}
@@ -0,0 +1,431 @@
+package twitterscraper
+
+import (
+	"bufio"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// ErrNoSession is returned by SessionStore.Load when no session was saved yet.
+var ErrNoSession = errors.New("session not found")
+
+// SessionStore keeps the blob of ExportSession between restarts, see WithSessionStore.
+type SessionStore interface {
+	// Load returns the saved session or ErrNoSession
+	Load(ctx context.Context) ([]byte, error)
+	// Save replaces the saved session
+	Save(ctx context.Context, session []byte) error
+}
+
+// NewWithSessionStore creates a Scraper, restores its session from the store if one was saved
+// and saves it back automatically, see WithSessionStore.
+func NewWithSessionStore(store SessionStore) (*Scraper, error) {
+	return NewWithSessionStoreContext(context.Background(), store)
+}
+
+// NewWithSessionStoreContext same as NewWithSessionStore, but accepts context for cancellation.
+func NewWithSessionStoreContext(ctx context.Context, store SessionStore) (*Scraper, error) {
+	s := New()
+	session, err := store.Load(ctx)
+	if err != nil && !errors.Is(err, ErrNoSession) {
+		return nil, err
+	}
+	if err == nil {
+		if err := s.ImportSession(session); err != nil {
+			return nil, err
+		}
+	}
+	return s.WithSessionStore(store), nil
+}
+
+// WithSessionStore save the session to the store after Login, LoginOpenAccount, Logout,
+// SetCookies, SetAuthToken, a successful IsLoggedIn and when a logged in response refreshes cookies.
+// Save errors are reported to the logger set with WithLogger.
+func (s *Scraper) WithSessionStore(store SessionStore) *Scraper {
+	s.mu.Lock()
+	s.sessionStore = store
+	s.mu.Unlock()
+	return s
+}
+
+// SaveSession save the session to the store set with WithSessionStore.
+func (s *Scraper) SaveSession() error {
+	return s.SaveSessionContext(context.Background())
+}
+
+// SaveSessionContext same as SaveSession, but accepts context for cancellation.
+func (s *Scraper) SaveSessionContext(ctx context.Context) error {
+	s.mu.RLock()
+	store := s.sessionStore
+	s.mu.RUnlock()
+	if store == nil {
+		return fmt.Errorf("session store is not set")
+	}
+	s.saveMu.Lock()
+	defer s.saveMu.Unlock()
+	session := s.session()
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	if err := store.Save(ctx, data); err != nil {
+		return err
+	}
+	saved := make(map[string]string)
+	for _, cookies := range session.Cookies {
+		for _, cookie := range cookies {
+			if isAuthCookie(cookie.Name) {
+				saved[cookie.Name] = cookie.Value
+			}
+		}
+	}
+	s.mu.Lock()
+	s.savedAuth = saved
+	s.mu.Unlock()
+	return nil
+}
+
+// sessionSaveTimeout bounds automatic saves, so an unreachable store doesn't stall requests for long
+const sessionSaveTimeout = 5 * time.Second
+
+// autoSaveSession saves the session if a store is set.
+func (s *Scraper) autoSaveSession() {
+	s.autoSaveSessionContext(context.Background())
+}
+
+// autoSaveSessionContext same as autoSaveSession, the save is bounded by ctx and sessionSaveTimeout.
+func (s *Scraper) autoSaveSessionContext(ctx context.Context) {
+	s.mu.RLock()
+	store, logger := s.sessionStore, s.logger
+	s.mu.RUnlock()
+	if store == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, sessionSaveTimeout)
+	defer cancel()
+	if err := s.SaveSessionContext(ctx); err != nil && logger != nil {
+		logger.Error("save session", "error", err)
+	}
+}
+
+// isAuthCookie reports whether the cookie holds the login, other cookies aren't worth saving on every response.
+func isAuthCookie(name string) bool {
+	return name == "auth_token" || name == "ct0"
+}
+
+// authCookiesChanged reports whether the response sets auth cookies different from the saved ones.
+func (s *Scraper) authCookiesChanged(resp *http.Response) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, cookie := range resp.Cookies() {
+		if isAuthCookie(cookie.Name) && s.savedAuth[cookie.Name] != cookie.Value {
+			return true
+		}
+	}
+	return false
+}
+
+// FileSessionStore keeps the session in a plain JSON file.
+type FileSessionStore struct {
+	Path string
+}
+
+// NewFileSessionStore creates FileSessionStore for the file.
+func NewFileSessionStore(path string) *FileSessionStore {
+	return &FileSessionStore{Path: path}
+}
+
+// Load implements SessionStore.
+func (f *FileSessionStore) Load(ctx context.Context) ([]byte, error) {
+	data, err := os.ReadFile(f.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoSession
+	}
+	return data, err
+}
+
+// Save implements SessionStore.
+func (f *FileSessionStore) Save(ctx context.Context, session []byte) error {
+	return writeFileAtomic(f.Path, session)
+}
+
+// writeFileAtomic replaces the file, readable by the owner only, so a crash never leaves half of the session.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// scrypt parameters recommended for interactive logins
+const (
+	scryptN       = 32768
+	scryptR       = 8
+	scryptP       = 1
+	scryptSaltLen = 16
+)
+
+// EncryptedFileSessionStore keeps the session in a file encrypted with AES-256-GCM,
+// the key is derived from the passphrase with scrypt.
+type EncryptedFileSessionStore struct {
+	Path string
+
+	passphrase []byte
+	mu         sync.Mutex
+	salt       []byte
+	key        []byte
+}
+
+// encryptedSession is the content of the encrypted file
+type encryptedSession struct {
+	Version int    `json:"version"`
+	Salt    []byte `json:"salt"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"`
+}
+
+// NewEncryptedFileSessionStore creates EncryptedFileSessionStore for the file.
+func NewEncryptedFileSessionStore(path, passphrase string) *EncryptedFileSessionStore {
+	return &EncryptedFileSessionStore{Path: path, passphrase: []byte(passphrase)}
+}
+
+// Load implements SessionStore.
+func (f *EncryptedFileSessionStore) Load(ctx context.Context) ([]byte, error) {
+	content, err := os.ReadFile(f.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoSession
+	}
+	if err != nil {
+		return nil, err
+	}
+	var encrypted encryptedSession
+	if err := json.Unmarshal(content, &encrypted); err != nil {
+		return nil, fmt.Errorf("decode encrypted session: %w", err)
+	}
+	if encrypted.Version != 1 {
+		return nil, fmt.Errorf("unsupported encrypted session version %d", encrypted.Version)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	aead, err := f.cipher(encrypted.Salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(encrypted.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("invalid encrypted session nonce")
+	}
+	session, err := aead.Open(nil, encrypted.Nonce, encrypted.Data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt session, wrong passphrase or corrupted file: %w", err)
+	}
+	return session, nil
+}
+
+// Save implements SessionStore.
+func (f *EncryptedFileSessionStore) Save(ctx context.Context, session []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	salt := f.salt
+	if salt == nil {
+		salt = make([]byte, scryptSaltLen)
+		if _, err := rand.Read(salt); err != nil {
+			return err
+		}
+	}
+	aead, err := f.cipher(salt)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	content, err := json.Marshal(encryptedSession{
+		Version: 1,
+		Salt:    salt,
+		Nonce:   nonce,
+		Data:    aead.Seal(nil, nonce, session, nil),
+	})
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(f.Path, content)
+}
+
+// cipher returns AES-GCM keyed by the passphrase and salt, the key of the last salt is cached
+// because scrypt is slow on purpose.
+func (f *EncryptedFileSessionStore) cipher(salt []byte) (cipher.AEAD, error) {
+	if f.key == nil || string(f.salt) != string(salt) {
+		key, err := scrypt.Key(f.passphrase, salt, scryptN, scryptR, scryptP, 32)
+		if err != nil {
+			return nil, err
+		}
+		f.salt, f.key = salt, key
+	}
+	block, err := aes.NewCipher(f.key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// RedisSessionStore keeps the session under a key of Redis or a server speaking its protocol, like KeyDB or Valkey.
+type RedisSessionStore struct {
+	// Addr of the server, like `localhost:6379`
+	Addr string
+	// Username for ACL, empty to authenticate with Password only
+	Username string
+	// Password, empty to skip authentication
+	Password string
+	// DB number selected before every command
+	DB int
+	// Key the session is stored under
+	Key string
+	// TTL of the key, 0 to keep it forever
+	TTL time.Duration
+	// DialTimeout is used when the context has no deadline, 10 seconds by default
+	DialTimeout time.Duration
+}
+
+// NewRedisSessionStore creates RedisSessionStore for the server and key.
+func NewRedisSessionStore(addr, key string) *RedisSessionStore {
+	return &RedisSessionStore{Addr: addr, Key: key}
+}
+
+// Load implements SessionStore.
+func (r *RedisSessionStore) Load(ctx context.Context) ([]byte, error) {
+	reply, err := r.command(ctx, "GET", r.Key)
+	if err != nil {
+		return nil, err
+	}
+	if reply == nil {
+		return nil, ErrNoSession
+	}
+	return reply, nil
+}
+
+// Save implements SessionStore.
+func (r *RedisSessionStore) Save(ctx context.Context, session []byte) error {
+	args := []string{"SET", r.Key, string(session)}
+	if r.TTL > 0 {
+		args = append(args, "PX", strconv.FormatInt(r.TTL.Milliseconds(), 10))
+	}
+	_, err := r.command(ctx, args...)
+	return err
+}
+
+// command connects, authenticates, selects the database and sends the command, returning its reply.
+func (r *RedisSessionStore) command(ctx context.Context, args ...string) ([]byte, error) {
+	timeout := r.DialTimeout
+	if timeout == 0 {
+		timeout = 10 * time.Second
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", r.Addr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	reader := bufio.NewReader(conn)
+	send := func(args ...string) ([]byte, error) {
+		if err := writeRESP(conn, args); err != nil {
+			return nil, err
+		}
+		return readRESP(reader)
+	}
+	if r.Password != "" {
+		auth := []string{"AUTH", r.Password}
+		if r.Username != "" {
+			auth = []string{"AUTH", r.Username, r.Password}
+		}
+		if _, err := send(auth...); err != nil {
+			return nil, err
+		}
+	}
+	if r.DB != 0 {
+		if _, err := send("SELECT", strconv.Itoa(r.DB)); err != nil {
+			return nil, err
+		}
+	}
+	return send(args...)
+}
+
+// writeRESP writes the command as an array of bulk strings.
+func writeRESP(w io.Writer, args []string) error {
+	buf := []byte("*" + strconv.Itoa(len(args)) + "\r\n")
+	for _, arg := range args {
+		buf = append(buf, "$"+strconv.Itoa(len(arg))+"\r\n"...)
+		buf = append(buf, arg...)
+		buf = append(buf, "\r\n"...)
+	}
+	_, err := w.Write(buf)
+	return err
+}
+
+// readRESP reads a simple string, integer or bulk string reply, nil for a null bulk string.
+func readRESP(r *bufio.Reader) ([]byte, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("redis: invalid reply %q", line)
+	}
+	line = line[:len(line)-2]
+	switch line[0] {
+	case '+', ':':
+		return []byte(line[1:]), nil
+	case '-':
+		return nil, fmt.Errorf("redis: %s", line[1:])
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid bulk length %q", line)
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		return data[:size], nil
+	}
+	return nil, fmt.Errorf("redis: unexpected reply %q", line)
+}
@@ -0,0 +1,317 @@
+package twitterscraper_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/einys/twitter-scraper"
+)
+
+// fakeRedis is an in-process stand-in for a Redis server supporting AUTH, SELECT, GET and SET.
+type fakeRedis struct {
+	password string
+	mu       sync.Mutex
+	data     map[string]string
+	listener net.Listener
+}
+
+func newFakeRedis(t *testing.T, password string) *fakeRedis {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &fakeRedis{password: password, data: make(map[string]string), listener: listener}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go r.serve(conn)
+		}
+	}()
+	return r
+}
+
+func (r *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	authenticated, db := r.password == "", "0"
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+		r.mu.Lock()
+		switch cmd := strings.ToUpper(args[0]); {
+		case cmd == "AUTH":
+			authenticated = args[len(args)-1] == r.password
+			if authenticated {
+				fmt.Fprint(conn, "+OK\r\n")
+			} else {
+				fmt.Fprint(conn, "-WRONGPASS invalid password\r\n")
+			}
+		case !authenticated:
+			fmt.Fprint(conn, "-NOAUTH Authentication required.\r\n")
+		case cmd == "SELECT":
+			db = args[1]
+			fmt.Fprint(conn, "+OK\r\n")
+		case cmd == "GET":
+			if value, ok := r.data[db+":"+args[1]]; ok {
+				fmt.Fprintf(conn, "$%d\r\n%s\r\n", len(value), value)
+			} else {
+				fmt.Fprint(conn, "$-1\r\n")
+			}
+		case cmd == "SET":
+			r.data[db+":"+args[1]] = args[2]
+			fmt.Fprint(conn, "+OK\r\n")
+		default:
+			fmt.Fprintf(conn, "-ERR unknown command '%s'\r\n", args[0])
+		}
+		r.mu.Unlock()
+	}
+}
+
+func (r *fakeRedis) get(key string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.data[key]
+}
+
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+	args := make([]string, n)
+	for i := range args {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+func TestFileSessionStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	store := twitterscraper.NewFileSessionStore(path)
+	scraper, err := twitterscraper.NewWithSessionStore(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatal("Expected no session file before the session changes")
+	}
+
+	scraper.SetAuthToken(twitterscraper.AuthToken{Token: "auth", CSRFToken: "ct0"})
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(saved, []byte(`"auth_token"`)) {
+		t.Errorf("Expected auth_token in saved session, got %s", saved)
+	}
+
+	restored, err := twitterscraper.NewWithSessionStore(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exported, _ := restored.ExportSession()
+	if !bytes.Equal(saved, exported) {
+		t.Errorf("Expected restored session to match saved one\n%s\n%s", saved, exported)
+	}
+}
+
+func TestEncryptedFileSessionStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.enc")
+	store := twitterscraper.NewEncryptedFileSessionStore(path, "passphrase")
+	if _, err := store.Load(context.Background()); !errors.Is(err, twitterscraper.ErrNoSession) {
+		t.Fatalf("Expected ErrNoSession, got %v", err)
+	}
+
+	session := []byte(`{"version":1,"cookies":{"twitter.com":[{"name":"auth_token","value":"secret"}]}}`)
+	if err := store.Save(context.Background(), session); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(content, []byte("secret")) || bytes.Contains(content, []byte("auth_token")) {
+		t.Error("Expected session to be encrypted")
+	}
+
+	loaded, err := twitterscraper.NewEncryptedFileSessionStore(path, "passphrase").Load(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(loaded, session) {
+		t.Errorf("Expected %s, got %s", session, loaded)
+	}
+	if _, err := twitterscraper.NewEncryptedFileSessionStore(path, "wrong").Load(context.Background()); err == nil {
+		t.Error("Expected error for wrong passphrase")
+	}
+}
+
+func TestRedisSessionStore(t *testing.T) {
+	redis := newFakeRedis(t, "password")
+	store := twitterscraper.NewRedisSessionStore(redis.listener.Addr().String(), "scraper:session")
+	if _, err := store.Load(context.Background()); err == nil || !strings.Contains(err.Error(), "NOAUTH") {
+		t.Fatalf("Expected NOAUTH error, got %v", err)
+	}
+	store.Password = "password"
+	store.DB = 2
+	if _, err := store.Load(context.Background()); !errors.Is(err, twitterscraper.ErrNoSession) {
+		t.Fatalf("Expected ErrNoSession, got %v", err)
+	}
+
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/verify_credentials.json"):
+			fmt.Fprint(w, `{}`)
+		case strings.HasSuffix(r.URL.Path, "/UserByScreenName"):
+			http.SetCookie(w, &http.Cookie{Name: "ct0", Value: "refreshed", Path: "/"})
+			fmt.Fprint(w, `{"data":{"user":{"result":{"rest_id":"783214","legacy":{"screen_name":"X","name":"X"}}}}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	scraper, err := twitterscraper.NewWithSessionStore(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scraper.WithBaseURL(server.URL)
+	scraper.SetAuthToken(twitterscraper.AuthToken{Token: "auth", CSRFToken: "ct0"})
+	if !scraper.IsLoggedIn() {
+		t.Fatal("Expected scraper to be logged in")
+	}
+	if saved := redis.get("2:scraper:session"); !strings.Contains(saved, `"is_logged_in":true`) {
+		t.Errorf("Expected logged in session to be saved, got %s", saved)
+	}
+
+	if _, err := scraper.GetProfile("X"); err != nil {
+		t.Fatal(err)
+	}
+	if saved := redis.get("2:scraper:session"); !strings.Contains(saved, `"refreshed"`) {
+		t.Errorf("Expected refreshed cookie to be saved, got %s", saved)
+	}
+
+	restored, err := twitterscraper.NewWithSessionStore(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exported, _ := restored.ExportSession()
+	var saved, restoredSession twitterscraper.Session
+	json.Unmarshal([]byte(redis.get("2:scraper:session")), &saved)
+	json.Unmarshal(exported, &restoredSession)
+	// cookies of twitter.com are mirrored to x.com on import
+	if !reflect.DeepEqual(saved.Cookies["twitter.com"], restoredSession.Cookies["twitter.com"]) ||
+		!reflect.DeepEqual(saved.Cookies["twitter.com"], restoredSession.Cookies["x.com"]) || !restoredSession.IsLoggedIn {
+		t.Errorf("Expected restored session to match saved one, got %s", exported)
+	}
+}
+
+// countingStore counts saves, blocking ones wait for the context to be done.
+type countingStore struct {
+	mu    sync.Mutex
+	saves int
+	block bool
+}
+
+func (c *countingStore) Load(ctx context.Context) ([]byte, error) {
+	return nil, twitterscraper.ErrNoSession
+}
+
+func (c *countingStore) Save(ctx context.Context, session []byte) error {
+	c.mu.Lock()
+	c.saves++
+	block := c.block
+	c.mu.Unlock()
+	if block {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return nil
+}
+
+func (c *countingStore) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.saves
+}
+
+func TestSessionStoreSavesOnAuthCookies(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("screen_name") {
+		case "refresh":
+			http.SetCookie(w, &http.Cookie{Name: "ct0", Value: "new" + r.Header.Get("X-Test")})
+		default:
+			http.SetCookie(w, &http.Cookie{Name: "lang", Value: "en"})
+		}
+		fmt.Fprint(w, `{}`)
+	})
+	store := &countingStore{}
+	scraper := twitterscraper.New().WithBaseURL(server.URL).WithSessionStore(store)
+	scraper.SetAuthToken(twitterscraper.AuthToken{Token: "auth", CSRFToken: "ct0"})
+	if !scraper.IsLoggedIn() {
+		t.Fatal("Expected to be logged in")
+	}
+	saves := store.count()
+
+	get := func(ctx context.Context, name string) {
+		req, err := http.NewRequestWithContext(ctx, "GET", server.URL+"/1.1/users/show.json?screen_name="+name, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		scraper.RequestAPI(req, nil)
+	}
+	get(context.Background(), "other")
+	if store.count() != saves {
+		t.Errorf("Expected no save for cookies other than auth ones, got %d saves", store.count()-saves)
+	}
+	get(context.Background(), "refresh")
+	get(context.Background(), "refresh")
+	if store.count() != saves+1 {
+		t.Errorf("Expected one save for refreshed ct0, got %d saves", store.count()-saves)
+	}
+
+	// an unreachable store doesn't outlive the request context
+	store.mu.Lock()
+	store.block = true
+	store.mu.Unlock()
+	scraper.WithRequestHook(func(req *http.Request) error {
+		req.Header.Set("X-Test", "er")
+		return nil
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	get(ctx, "refresh")
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected save bounded by the request context, took %s", elapsed)
+	}
+}
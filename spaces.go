@@ -1,61 +1,32 @@
 package twitterscraper
 
 import (
-	"errors"
-	"net/url"
+	"context"
+	"fmt"
 	"time"
 )
 
 func (s *Scraper) GetSpace(id string) (*Space, error) {
-	if !s.isLogged {
-		return nil, errors.New("scraper is not logged in")
+	return s.GetSpaceContext(context.Background(), id)
+}
+
+// GetSpaceContext same as GetSpace, but accepts context for cancellation.
+func (s *Scraper) GetSpaceContext(ctx context.Context, id string) (*Space, error) {
+	if !s.loggedIn() {
+		return nil, ErrNotLoggedIn
 	}
 
-	req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/d03OdorPdZ_sH9V3D1_yWQ/AudioSpaceById")
+	op := s.operation("AudioSpaceById")
+	req, err := s.newRequest(ctx, op.Method, op.URL())
 	if err != nil {
 		return nil, err
 	}
 
 	variables := map[string]interface{}{
-		"id":              id,
-		"isMetatagsQuery": false,
-		"withReplays":     true,
-		"withListeners":   true,
-	}
-
-	features := map[string]interface{}{
-		"spaces_2022_h2_spaces_communities":                                       true,
-		"spaces_2022_h2_clipping":                                                 true,
-		"creator_subscriptions_tweet_preview_api_enabled":                         true,
-		"rweb_tipjar_consumption_enabled":                                         true,
-		"responsive_web_graphql_exclude_directive_enabled":                        true,
-		"verified_phone_label_enabled":                                            false,
-		"communities_web_enable_tweet_community_results_fetch":                    true,
-		"c9s_tweet_anatomy_moderator_badge_enabled":                               true,
-		"articles_preview_enabled":                                                true,
-		"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
-		"tweetypie_unmention_optimization_enabled":                                true,
-		"responsive_web_edit_tweet_api_enabled":                                   true,
-		"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
-		"view_counts_everywhere_api_enabled":                                      true,
-		"longform_notetweets_consumption_enabled":                                 true,
-		"responsive_web_twitter_article_tweet_consumption_enabled":                true,
-		"tweet_awards_web_tipping_enabled":                                        false,
-		"creator_subscriptions_quote_tweet_preview_enabled":                       false,
-		"freedom_of_speech_not_reach_fetch_enabled":                               true,
-		"standardized_nudges_misinfo":                                             true,
-		"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": true,
-		"rweb_video_timestamps_enabled":                                           true,
-		"longform_notetweets_rich_text_read_enabled":                              true,
-		"longform_notetweets_inline_media_enabled":                                true,
-		"responsive_web_graphql_timeline_navigation_enabled":                      true,
-		"responsive_web_enhance_cards_enabled":                                    false,
+		"id": id,
 	}
 
-	query := url.Values{}
-	query.Set("variables", mapToJSONString(variables))
-	query.Set("features", mapToJSONString(features))
-	req.URL.RawQuery = query.Encode()
+	op.encode(req, variables)
 
 	var spaceData space
 	err = s.RequestAPI(req, &spaceData)
@@ -66,7 +37,7 @@ func (s *Scraper) GetSpace(id string) (*Space, error) {
 	space := spaceData.parse()
 
 	if space.ID == "" {
-		return nil, errors.New("some erorr happend")
+		return nil, fmt.Errorf("space %s %w", id, ErrNotFound)
 	}
 
 	return space, nil
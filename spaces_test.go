@@ -13,6 +13,7 @@ func TestGetSpace(t *testing.T) {
 	spaceId := "1OdJrXPVLEnKX"
 
 	space, err := testScraper.GetSpace(spaceId)
+	skipIfNotRecorded(t, err)
 	if err != nil {
 		t.Fatal(err)
 	}
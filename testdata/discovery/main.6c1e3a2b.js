@@ -0,0 +1,7 @@
+(self.webpackChunk_twitter_responsive_web=self.webpackChunk_twitter_responsive_web||[]).push([["main"],{
+37519:e=>{e.exports={queryId:"Q6aAvPw7azXZbqXzuqTALA",operationName:"UserTweets",operationType:"query",metadata:{featureSwitches:["rweb_tipjar_consumption_enabled","responsive_web_graphql_timeline_navigation_enabled","rweb_video_timestamps_enabled","longform_notetweets_inline_media_enabled"],fieldToggles:["withArticlePlainText"]}}},
+64412:e=>{e.exports={queryId:"xmU6X_CKVnQ5lSrCbAmJsg",operationName:"UserByScreenName",operationType:"query",metadata:{featureSwitches:["responsive_web_graphql_timeline_navigation_enabled","hidden_profile_subscriptions_enabled"],fieldToggles:["withAuxiliaryUserLabels"]}}},
+90227:e=>{e.exports={queryId:"lI07N6Otwv1PhnEgXILM7A",operationName:"FavoriteTweet",operationType:"mutation",metadata:{featureSwitches:[],fieldToggles:[]}}},
+11043:e=>{e.exports={queryId:"Vx4BmDBxrTX2mGo3Kq7Ckw",operationName:"CommunityQuery",operationType:"query",metadata:{featureSwitches:["c9s_tweet_anatomy_moderator_badge_enabled"],fieldToggles:[]}}},
+52110:(e,t,n)=>{"use strict";n.d(t,{Z:()=>a});const a=n(37519)}
+}]);
@@ -0,0 +1 @@
+"use strict";(self.webpackChunk_twitter_responsive_web=self.webpackChunk_twitter_responsive_web||[]).push([["ondemand.s"],{472233:(W,p,d)=>{d.d(p,{default:()=>s});const s=async(W,p)=>{const d=o(),n=[parseInt(c(d[7], 16)),parseInt(c(d[22], 16)),parseInt(c(d[35], 16)),parseInt(c(d[41], 16))];return n.reduce((W,p)=>W*p,1)}}}]);
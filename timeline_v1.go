@@ -109,6 +109,9 @@ func (timeline *timelineV1) parseTweet(id string) *Tweet {
 		if tweet.Place.ID != "" {
 			tw.Place = &tweet.Place
 		}
+		tweet.editControl().apply(tw)
+		tw.CommunityNote = tweet.Ext.BirdwatchPivot.R.Ok.parse()
+		tw.Card, tw.Poll = tweet.Card.parse()
 
 		if tweet.QuotedStatusIDStr != "" {
 			tw.IsQuoted = true
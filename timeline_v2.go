@@ -25,18 +25,35 @@ type tweet struct {
 	QuotedStatusResult struct {
 		Result *result `json:"result"`
 	} `json:"quoted_status_result"`
-	Legacy legacyTweet `json:"legacy"`
-	Card   struct {
-		RestID string `json:"rest_id"`
-		Legacy struct {
-			BindingValues []struct {
-				Key   string `json:"key"`
-				Value struct {
-					StringValue string `json:"string_value"`
-				} `json:"value"`
-			} `json:"binding_values"`
-		} `json:"legacy"`
-	} `json:"card"`
+	Legacy         legacyTweet     `json:"legacy"`
+	EditControl    *editControl    `json:"edit_control"`
+	BirdwatchPivot *birdwatchPivot `json:"birdwatch_pivot"`
+	Card           tweetCard       `json:"card"`
+}
+
+type tweetCard struct {
+	RestID string `json:"rest_id"`
+	Legacy struct {
+		Name          string `json:"name"`
+		URL           string `json:"url"`
+		BindingValues []struct {
+			Key   string           `json:"key"`
+			Value cardBindingValue `json:"value"`
+		} `json:"binding_values"`
+	} `json:"legacy"`
+}
+
+// parse returns the card and the poll it holds, if any.
+func (c *tweetCard) parse() (*Card, *Poll) {
+	values := make(map[string]cardBindingValue, len(c.Legacy.BindingValues))
+	for _, v := range c.Legacy.BindingValues {
+		values[v.Key] = v.Value
+	}
+	card, poll := parseCard(c.Legacy.Name, c.Legacy.URL, values)
+	if poll != nil && strings.HasPrefix(c.RestID, "card://") {
+		poll.ID = strings.TrimPrefix(c.RestID, "card://")
+	}
+	return card, poll
 }
 
 type result struct {
@@ -78,16 +95,29 @@ func (result *result) parse() *Tweet {
 	}
 	var legacy *legacyTweet = &result.Legacy
 	var user *UserV2 = &result.Core.UserResults.Result
+	var edit *editControl = result.EditControl
+	var pivot *birdwatchPivot = result.BirdwatchPivot
+	var card *tweetCard = &result.Card
 
 	if result.Typename == "TweetWithVisibilityResults" {
 		legacy = &result.Tweet.Legacy
 		user = &result.Tweet.Core.UserResults.Result
+		edit = result.Tweet.EditControl
+		pivot = result.Tweet.BirdwatchPivot
+		card = &result.Tweet.Card
 	}
 
 	// --------
 	tw := parseLegacyTweet(user, legacy) // 여기서 선택된 필드만 추출됨
 	// --------
 
+	edit.apply(tw)
+	if note := pivot.parse(); note != nil {
+		tw.CommunityNote = note
+	}
+	if c, poll := card.parse(); c != nil {
+		tw.Card, tw.Poll = c, poll
+	}
 	if tw.Views == 0 && result.Views.Count != "" {
 		tw.Views, _ = strconv.Atoi(result.Views.Count)
 	}
@@ -96,7 +126,7 @@ func (result *result) parse() *Tweet {
 	}
 
 	// Get videos from cards
-	for _, v := range result.Tweet.Card.Legacy.BindingValues {
+	for _, v := range card.Legacy.BindingValues {
 		if v.Key == "unified_card" {
 			var card UnifiedCard
 			err := json.Unmarshal([]byte(v.Value.StringValue), &card)
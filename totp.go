@@ -0,0 +1,75 @@
+package twitterscraper
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// totpPeriod is the time step of authenticator apps
+	totpPeriod = 30 * time.Second
+	totpDigits = 6
+)
+
+// GenerateTOTP returns the 6 digit code of the base32 secret at the time, as authenticator apps do (RFC 6238).
+// Spaces and lowercase letters in the secret are accepted.
+func GenerateTOTP(secret string, at time.Time) (string, error) {
+	key, err := decodeTOTPSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(at.Unix()/int64(totpPeriod/time.Second)))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	// dynamic truncation of RFC 4226
+	offset := sum[len(sum)-1] & 0x0f
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, code%1000000), nil
+}
+
+func decodeTOTPSecret(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.Join(strings.Fields(secret), ""))
+	secret = strings.TrimRight(secret, "=")
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TOTP secret: %w", err)
+	}
+	if len(key) == 0 {
+		return nil, fmt.Errorf("invalid TOTP secret: empty")
+	}
+	return key, nil
+}
+
+// TOTPChallengeHandler answers `LoginTwoFactorAuthChallenge` with the code of the base32 secret
+// generated when the challenge is reached. If the code is rejected, the next one is awaited.
+func TOTPChallengeHandler(secret string) ChallengeHandler {
+	return func(ctx context.Context, challenge Challenge) (string, error) {
+		if challenge.Kind != ChallengeTwoFactor {
+			return "", ErrChallengeNotHandled
+		}
+		if challenge.Attempt > 1 {
+			// the code of the current period was already used
+			now := time.Now()
+			if err := sleepContext(ctx, now.Truncate(totpPeriod).Add(totpPeriod).Sub(now)); err != nil {
+				return "", err
+			}
+		}
+		return GenerateTOTP(secret, time.Now())
+	}
+}
+
+// WithTOTPSecret generate 2FA codes during Login from the base32 secret of the authenticator app,
+// same as `WithChallengeHandler(TOTPChallengeHandler(secret))`
+func (s *Scraper) WithTOTPSecret(secret string) *Scraper {
+	return s.WithChallengeHandler(TOTPChallengeHandler(secret))
+}
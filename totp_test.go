@@ -0,0 +1,80 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/einys/twitter-scraper"
+)
+
+// rfc6238Secret is the SHA1 seed of RFC 6238 test vectors, `12345678901234567890` in base32
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestGenerateTOTP(t *testing.T) {
+	// RFC 6238 appendix B, the last 6 of 8 digits
+	tests := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+	for unix, expected := range tests {
+		code, err := twitterscraper.GenerateTOTP(rfc6238Secret, time.Unix(unix, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code != expected {
+			t.Errorf("Expected %s at %d, got %s", expected, unix, code)
+		}
+	}
+
+	if code, _ := twitterscraper.GenerateTOTP("gezd gnbv gy3t qojq gezd gnbv gy3t qojq", time.Unix(59, 0)); code != "287082" {
+		t.Errorf("Expected lowercase secret with spaces to be accepted, got %s", code)
+	}
+	if _, err := twitterscraper.GenerateTOTP("not base32!", time.Now()); err == nil {
+		t.Error("Expected error for invalid secret")
+	}
+}
+
+func TestTOTPChallengeHandler(t *testing.T) {
+	handler := twitterscraper.TOTPChallengeHandler(rfc6238Secret)
+	if _, err := handler(context.Background(), twitterscraper.Challenge{Kind: twitterscraper.ChallengeEmail}); !errors.Is(err, twitterscraper.ErrChallengeNotHandled) {
+		t.Errorf("Expected ErrChallengeNotHandled for email challenge, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := handler(ctx, twitterscraper.Challenge{Kind: twitterscraper.ChallengeTwoFactor, Attempt: 2}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected retry to wait for the next code, got %v", err)
+	}
+}
+
+func TestLoginTOTP(t *testing.T) {
+	var code string
+	var reached time.Time
+	server := newLoginServer(t, func(subtaskID, text string) string {
+		switch subtaskID {
+		case "":
+			reached = time.Now()
+			return `{"subtask_id":"LoginTwoFactorAuthChallenge"}`
+		case "LoginTwoFactorAuthChallenge":
+			code = text
+			return `{"subtask_id":"LoginSuccessSubtask"}`
+		}
+		return ""
+	})
+	scraper := twitterscraper.New().WithBaseURL(server.URL).WithLoginDelay(0, 0).WithTOTPSecret(rfc6238Secret)
+	if err := scraper.Login("username", "password"); err != nil {
+		t.Fatal(err)
+	}
+
+	current, _ := twitterscraper.GenerateTOTP(rfc6238Secret, reached)
+	next, _ := twitterscraper.GenerateTOTP(rfc6238Secret, reached.Add(30*time.Second))
+	if code != current && code != next {
+		t.Errorf("Expected code of the moment the challenge was reached, got %s", code)
+	}
+}
@@ -0,0 +1,328 @@
+package twitterscraper
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// transactionKeyword is mixed into the hash by the web client
+	transactionKeyword = "obfiowerehiring"
+	// transactionExtraByte is appended after the hash by the web client
+	transactionExtraByte = 3
+	// transactionEpoch is the start of the transaction clock in Unix seconds
+	transactionEpoch = 1682924400
+	// animationTotalTime is the duration of the loading animation in milliseconds
+	animationTotalTime = 4096
+	onDemandFileURL    = "https://abs.twimg.com/responsive-web/client-web/ondemand.s.%sa.js"
+)
+
+var (
+	reMetaTag           = regexp.MustCompile(`<meta[^>]+>`)
+	reMetaContent       = regexp.MustCompile(`content="([^"]+)"`)
+	reOnDemandFile      = regexp.MustCompile(`['"]ondemand\.s['"]:\s*['"](\w*)['"]`)
+	reKeyByteIndex      = regexp.MustCompile(`\(\w\[(\d{1,2})\],\s*16\)`)
+	reAnimationFrame    = regexp.MustCompile(`(?s)<svg[^>]+id="loading-x-anim-\d+"[^>]*>(.*?)</svg>`)
+	rePathData          = regexp.MustCompile(`<path\b[^>]*\sd="([^"]+)"`)
+	reNonDigits         = regexp.MustCompile(`[^\d]+`)
+	verificationKeyName = `name="twitter-site-verification"`
+)
+
+// ClientTransaction generates `x-client-transaction-id` header values the same way the web client does.
+// It is derived from the verification key and loading animation of the home page
+// and the key byte indices of the ondemand.s script, they change with every web client release.
+type ClientTransaction struct {
+	keyBytes     []byte
+	animationKey string
+}
+
+// OnDemandFileURL returns URL of the ondemand.s script referenced in the home page.
+func OnDemandFileURL(homePage []byte) (string, error) {
+	match := reOnDemandFile.FindSubmatch(homePage)
+	if match == nil {
+		return "", fmt.Errorf("ondemand.s file not found in the home page")
+	}
+	return fmt.Sprintf(onDemandFileURL, match[1]), nil
+}
+
+// NewClientTransaction creates ClientTransaction from the HTML of the home page and the ondemand.s script.
+func NewClientTransaction(homePage, onDemandFile []byte) (*ClientTransaction, error) {
+	var key string
+	for _, tag := range reMetaTag.FindAll(homePage, -1) {
+		if strings.Contains(string(tag), verificationKeyName) {
+			if match := reMetaContent.FindSubmatch(tag); match != nil {
+				key = string(match[1])
+			}
+		}
+	}
+	if key == "" {
+		return nil, fmt.Errorf("twitter-site-verification key not found in the home page")
+	}
+	keyBytes, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("decode twitter-site-verification key: %w", err)
+	}
+
+	var indices []int
+	for _, match := range reKeyByteIndex.FindAllSubmatch(onDemandFile, -1) {
+		index, _ := strconv.Atoi(string(match[1]))
+		if index >= len(keyBytes) {
+			return nil, fmt.Errorf("key byte index %d out of %d key bytes", index, len(keyBytes))
+		}
+		indices = append(indices, index)
+	}
+	if len(indices) < 2 {
+		return nil, fmt.Errorf("key byte indices not found in the ondemand.s file")
+	}
+
+	frames := reAnimationFrame.FindAllSubmatch(homePage, -1)
+	if len(frames) < 4 || len(keyBytes) < 6 {
+		return nil, fmt.Errorf("loading animation not found in the home page")
+	}
+	paths := rePathData.FindAllSubmatch(frames[keyBytes[5]%4][1], -1)
+	if len(paths) < 2 || len(paths[1][1]) < 9 {
+		return nil, fmt.Errorf("loading animation frame has no path")
+	}
+	var rows [][]float64
+	for _, segment := range strings.Split(string(paths[1][1][9:]), "C") {
+		var row []float64
+		for _, field := range strings.Fields(reNonDigits.ReplaceAllString(segment, " ")) {
+			value, _ := strconv.Atoi(field)
+			row = append(row, float64(value))
+		}
+		rows = append(rows, row)
+	}
+
+	rowIndex := int(keyBytes[indices[0]] % 16)
+	if rowIndex >= len(rows) || len(rows[rowIndex]) < 11 {
+		return nil, fmt.Errorf("loading animation frame has no row %d", rowIndex)
+	}
+	frameTime := 1.0
+	for _, index := range indices[1:] {
+		frameTime *= float64(keyBytes[index] % 16)
+	}
+	frameTime = jsRound(frameTime/10) * 10
+
+	return &ClientTransaction{
+		keyBytes:     keyBytes,
+		animationKey: animate(rows[rowIndex], frameTime/animationTotalTime),
+	}, nil
+}
+
+// AnimationKey returns the key computed from the loading animation frame.
+func (t *ClientTransaction) AnimationKey() string {
+	return t.animationKey
+}
+
+// Generate returns the transaction id for the request method and URL path, like `/i/api/graphql/{id}/UserTweets`.
+func (t *ClientTransaction) Generate(method, path string) string {
+	random := make([]byte, 1)
+	rand.Read(random)
+	return t.generate(method, path, time.Now(), random[0])
+}
+
+func (t *ClientTransaction) generate(method, path string, now time.Time, random byte) string {
+	timeNow := uint32(now.Unix() - transactionEpoch)
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s!%s!%d%s%s", method, path, timeNow, transactionKeyword, t.animationKey)))
+
+	data := make([]byte, 0, len(t.keyBytes)+4+16+1)
+	data = append(data, t.keyBytes...)
+	data = append(data, byte(timeNow), byte(timeNow>>8), byte(timeNow>>16), byte(timeNow>>24))
+	data = append(data, hash[:16]...)
+	data = append(data, transactionExtraByte)
+
+	out := make([]byte, 1, len(data)+1)
+	out[0] = random
+	for _, b := range data {
+		out = append(out, b^random)
+	}
+	return base64.RawStdEncoding.EncodeToString(out)
+}
+
+// animate computes color and rotation of the animation frame at the time and encodes them to hex.
+func animate(frame []float64, targetTime float64) string {
+	fromColor := []float64{frame[0], frame[1], frame[2], 1}
+	toColor := []float64{frame[3], frame[4], frame[5], 1}
+	toRotation := solve(frame[6], 60, 360, true)
+
+	curves := make([]float64, 0, len(frame)-7)
+	for i, value := range frame[7:] {
+		min := 0.0
+		if i%2 == 1 {
+			min = -1
+		}
+		curves = append(curves, solve(value, min, 1, false))
+	}
+	value := cubicValue(curves, targetTime)
+
+	var b strings.Builder
+	for i := 0; i < 3; i++ {
+		color := math.Max(fromColor[i]*(1-value)+toColor[i]*value, 0)
+		fmt.Fprintf(&b, "%02x", int64(math.RoundToEven(color)))
+	}
+	rad := (toRotation * value) * (math.Pi / 180)
+	for _, m := range []float64{math.Cos(rad), -math.Sin(rad), math.Sin(rad), math.Cos(rad)} {
+		hex := floatToHex(math.Abs(roundTo2(m)))
+		switch {
+		case strings.HasPrefix(hex, "."):
+			b.WriteString("0" + strings.ToLower(hex))
+		case hex == "":
+			b.WriteString("0")
+		default:
+			b.WriteString(hex)
+		}
+	}
+	b.WriteString("00")
+	return strings.NewReplacer(".", "", "-", "").Replace(b.String())
+}
+
+// solve scales the byte value to the range.
+func solve(value, min, max float64, floor bool) float64 {
+	result := value*(max-min)/255 + min
+	if floor {
+		return math.Floor(result)
+	}
+	return roundTo2(result)
+}
+
+// cubicValue evaluates the cubic bezier easing curve at the time.
+func cubicValue(curves []float64, t float64) float64 {
+	if t <= 0 {
+		var gradient float64
+		if curves[0] > 0 {
+			gradient = curves[1] / curves[0]
+		} else if curves[1] == 0 && curves[2] > 0 {
+			gradient = curves[3] / curves[2]
+		}
+		return gradient * t
+	}
+	if t >= 1 {
+		var gradient float64
+		if curves[2] < 1 {
+			gradient = (curves[3] - 1) / (curves[2] - 1)
+		} else if curves[2] == 1 && curves[0] < 1 {
+			gradient = (curves[1] - 1) / (curves[0] - 1)
+		}
+		return 1 + gradient*(t-1)
+	}
+
+	start, end, mid := 0.0, 1.0, 0.0
+	for start < end {
+		mid = (start + end) / 2
+		estimate := bezier(curves[0], curves[2], mid)
+		if math.Abs(t-estimate) < 0.00001 {
+			return bezier(curves[1], curves[3], mid)
+		}
+		if estimate < t {
+			start = mid
+		} else {
+			end = mid
+		}
+	}
+	return bezier(curves[1], curves[3], mid)
+}
+
+func bezier(a, b, m float64) float64 {
+	return 3*a*(1-m)*(1-m)*m + 3*b*(1-m)*m*m + m*m*m
+}
+
+// floatToHex formats non-negative number in hex with fraction, like `.B8` for 0.71875.
+func floatToHex(x float64) string {
+	var result []byte
+	quotient := math.Trunc(x)
+	fraction := x - quotient
+	for quotient > 0 {
+		quotient = math.Trunc(x / 16)
+		remainder := int(x - quotient*16)
+		result = append([]byte{hexDigit(remainder)}, result...)
+		x = quotient
+	}
+	if fraction == 0 {
+		return string(result)
+	}
+	result = append(result, '.')
+	for fraction > 0 {
+		fraction *= 16
+		integer := math.Trunc(fraction)
+		fraction -= integer
+		result = append(result, hexDigit(int(integer)))
+	}
+	return string(result)
+}
+
+func hexDigit(d int) byte {
+	if d > 9 {
+		return byte(d + 55)
+	}
+	return byte('0' + d)
+}
+
+// roundTo2 rounds to 2 decimal places like Python round, on the exact binary value.
+func roundTo2(x float64) float64 {
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(x, 'f', 2, 64), 64)
+	return rounded
+}
+
+// jsRound rounds half up like Math.round in JavaScript.
+func jsRound(x float64) float64 {
+	rounded := math.Floor(x)
+	if x-rounded >= 0.5 {
+		rounded = math.Ceil(x)
+	}
+	return math.Copysign(rounded, x)
+}
+
+// WithClientTransaction generate `x-client-transaction-id` headers with the transaction instead of random values.
+func (s *Scraper) WithClientTransaction(transaction *ClientTransaction) *Scraper {
+	s.mu.Lock()
+	s.transaction = transaction
+	s.mu.Unlock()
+	return s
+}
+
+// LoadClientTransaction fetch the home page and its ondemand.s script and generate
+// `x-client-transaction-id` headers from them, see ClientTransaction.
+func (s *Scraper) LoadClientTransaction() error {
+	return s.LoadClientTransactionContext(context.Background())
+}
+
+// LoadClientTransactionContext same as LoadClientTransaction, but accepts context for cancellation.
+func (s *Scraper) LoadClientTransactionContext(ctx context.Context) error {
+	homePage, err := s.fetchPage(ctx, s.cookieURL().String())
+	if err != nil {
+		return err
+	}
+	fileURL, err := OnDemandFileURL(homePage)
+	if err != nil {
+		return err
+	}
+	onDemandFile, err := s.fetchPage(ctx, fileURL)
+	if err != nil {
+		return err
+	}
+	transaction, err := NewClientTransaction(homePage, onDemandFile)
+	if err != nil {
+		return err
+	}
+	s.WithClientTransaction(transaction)
+	return nil
+}
+
+// transactionID returns `x-client-transaction-id` for the request, random unless a transaction was loaded.
+func (s *Scraper) transactionID(method, path string) string {
+	s.mu.RLock()
+	transaction := s.transaction
+	s.mu.RUnlock()
+	if transaction == nil {
+		return generateTxnID()
+	}
+	return transaction.Generate(method, path)
+}
@@ -0,0 +1,162 @@
+package twitterscraper_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/einys/twitter-scraper"
+)
+
+const (
+	transactionPage         = "testdata/transaction/index.html"
+	transactionOnDemandFile = "testdata/transaction/ondemand.s.9a7c2e51a.js"
+	transactionAnimationKey = "71e058101999999999999a01999999999999a100"
+)
+
+func newTestTransaction(t *testing.T) (*twitterscraper.ClientTransaction, []byte) {
+	homePage, err := os.ReadFile(transactionPage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	onDemandFile, err := os.ReadFile(transactionOnDemandFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	transaction, err := twitterscraper.NewClientTransaction(homePage, onDemandFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return transaction, homePage
+}
+
+// checkTransactionID decodes the id and checks its key bytes, time and hash of method and path.
+func checkTransactionID(t *testing.T, id, method, path string, homePage []byte) {
+	t.Helper()
+	data, err := base64.RawStdEncoding.DecodeString(id)
+	if err != nil {
+		t.Fatalf("Invalid transaction id %q: %v", id, err)
+	}
+	for i := 1; i < len(data); i++ {
+		data[i] ^= data[0]
+	}
+	data = data[1:]
+
+	key := []byte(`name="twitter-site-verification" content="`)
+	start := bytes.Index(homePage, key) + len(key)
+	keyBytes, _ := base64.StdEncoding.DecodeString(string(homePage[start : start+bytes.IndexByte(homePage[start:], '"')]))
+	if len(data) != len(keyBytes)+4+16+1 {
+		t.Fatalf("Expected %d bytes, got %d", len(keyBytes)+21, len(data))
+	}
+	if !bytes.Equal(data[:len(keyBytes)], keyBytes) {
+		t.Error("Expected transaction id to start with the verification key")
+	}
+
+	timeNow := binary.LittleEndian.Uint32(data[len(keyBytes):])
+	if elapsed := time.Now().Unix() - 1682924400 - int64(timeNow); elapsed < 0 || elapsed > 60 {
+		t.Errorf("Unexpected transaction time %d", timeNow)
+	}
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s!%s!%dobfiowerehiring%s", method, path, timeNow, transactionAnimationKey)))
+	if !bytes.Equal(data[len(keyBytes)+4:len(keyBytes)+20], hash[:16]) {
+		t.Error("Expected transaction id to contain hash of method and path")
+	}
+	if data[len(data)-1] != 3 {
+		t.Errorf("Expected last byte 3, got %d", data[len(data)-1])
+	}
+}
+
+func TestClientTransaction(t *testing.T) {
+	transaction, homePage := newTestTransaction(t)
+	if key := transaction.AnimationKey(); key != transactionAnimationKey {
+		t.Errorf("Expected animation key %s, got %s", transactionAnimationKey, key)
+	}
+
+	path := "/i/api/graphql/Q6aAvPw7azXZbqXzuqTALA/UserTweets"
+	id := transaction.Generate("GET", path)
+	checkTransactionID(t, id, "GET", path, homePage)
+
+	if url, err := twitterscraper.OnDemandFileURL(homePage); err != nil || url != "https://abs.twimg.com/responsive-web/client-web/ondemand.s.9a7c2e51a.js" {
+		t.Errorf("Unexpected ondemand.s URL %s: %v", url, err)
+	}
+}
+
+func TestClientTransactionErrors(t *testing.T) {
+	homePage, err := os.ReadFile(transactionPage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := twitterscraper.NewClientTransaction([]byte("<html></html>"), nil); err == nil {
+		t.Error("Expected error without verification key")
+	}
+	if _, err := twitterscraper.NewClientTransaction(homePage, []byte("no indices")); err == nil {
+		t.Error("Expected error without key byte indices")
+	}
+	withoutFrames := []byte(strings.Split(string(homePage), "<svg")[0])
+	onDemandFile, _ := os.ReadFile(transactionOnDemandFile)
+	if _, err := twitterscraper.NewClientTransaction(withoutFrames, onDemandFile); err == nil {
+		t.Error("Expected error without animation frames")
+	}
+}
+
+func TestClientTransactionHeader(t *testing.T) {
+	transaction, homePage := newTestTransaction(t)
+	var checked int32
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/UserTweets") {
+			checkTransactionID(t, r.Header.Get("X-Client-Transaction-Id"), r.Method, r.URL.Path, homePage)
+			atomic.AddInt32(&checked, 1)
+		}
+		fmt.Fprint(w, `{}`)
+	})
+	scraper := twitterscraper.New().WithBaseURL(server.URL).WithClientTransaction(transaction)
+	if _, _, err := scraper.FetchTweetsByUserID("783214", 20, ""); err != nil {
+		t.Fatal(err)
+	}
+	if atomic.LoadInt32(&checked) != 1 {
+		t.Error("Expected UserTweets request")
+	}
+}
+
+func TestClientTransactionHeaderAuth(t *testing.T) {
+	transaction, homePage := newTestTransaction(t)
+	var mu sync.Mutex
+	checked := make(map[string]bool)
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		checkTransactionID(t, r.Header.Get("X-Client-Transaction-Id"), r.Method, r.URL.Path, homePage)
+		mu.Lock()
+		checked[r.URL.Path] = true
+		mu.Unlock()
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/onboarding/task.json"):
+			fmt.Fprint(w, `{"flow_token":"flow","status":"success","subtasks":[{"subtask_id":"OpenAccount","open_account":{"oauth_token":"token","oauth_token_secret":"secret"}}]}`)
+		default:
+			fmt.Fprint(w, `{}`)
+		}
+	})
+	scraper := twitterscraper.New().WithBaseURL(server.URL).WithClientTransaction(transaction)
+	if _, err := scraper.LoginOpenAccount(); err != nil {
+		t.Fatal(err)
+	}
+	if !scraper.IsLoggedIn() {
+		t.Fatal("Expected to be logged in")
+	}
+	if err := scraper.Logout(); err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	for _, path := range []string{"/1.1/onboarding/task.json", "/1.1/account/verify_credentials.json", "/1.1/account/logout.json"} {
+		if !checked[path] {
+			t.Errorf("Expected request to %s, got %v", path, checked)
+		}
+	}
+}
@@ -1,10 +1,18 @@
 package twitterscraper
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // GetTrends return list of trends.
 func (s *Scraper) GetTrends() ([]string, error) {
-	req, err := s.newRequest("GET", "https://api.twitter.com/2/guide.json")
+	return s.GetTrendsContext(context.Background())
+}
+
+// GetTrendsContext same as GetTrends, but accepts context for cancellation.
+func (s *Scraper) GetTrendsContext(ctx context.Context) ([]string, error) {
+	req, err := s.newRequest(ctx, "GET", "https://api.twitter.com/2/guide.json")
 	if err != nil {
 		return nil, err
 	}
@@ -17,14 +25,8 @@ func (s *Scraper) GetTrends() ([]string, error) {
 	req.URL.RawQuery = q.Encode()
 
 	var jsn timelineV1
-	curBearerToken := s.bearerToken
-	if curBearerToken != bearerToken2 {
-		s.setBearerToken(bearerToken2)
-	}
+	req = req.WithContext(ContextWithBearerToken(req.Context(), bearerToken2))
 	err = s.RequestAPI(req, &jsn)
-	if curBearerToken != bearerToken2 {
-		s.setBearerToken(curBearerToken)
-	}
 	if err != nil {
 		return nil, err
 	}
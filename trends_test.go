@@ -9,6 +9,7 @@ func TestGetTrends(t *testing.T) {
 		t.Skip("Skipping test due to environment variable")
 	}
 	trends, err := testScraper.GetTrends()
+	skipIfNotRecorded(t, err)
 	if err != nil {
 		t.Fatal(err)
 	}
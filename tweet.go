@@ -1,11 +1,8 @@
 package twitterscraper
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"errors"
-	"io"
-	"net/url"
 	"strconv"
 	"strings"
 )
@@ -47,13 +44,17 @@ func (newTweet *newTweet) parse() *Tweet {
 }
 
 func (s *Scraper) CreateTweet(tweet NewTweet) (*Tweet, error) {
-	req, err := s.newRequest("POST", "https://twitter.com/i/api/graphql/oB-5XsHNAbjvARJEc8CZFw/CreateTweet")
+	return s.CreateTweetContext(context.Background(), tweet)
+}
+
+// CreateTweetContext same as CreateTweet, but accepts context for cancellation.
+func (s *Scraper) CreateTweetContext(ctx context.Context, tweet NewTweet) (*Tweet, error) {
+	op := s.operation("CreateTweet")
+	req, err := s.newRequest(ctx, op.Method, op.URL())
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("content-type", "application/json")
-
 	media_entities := []map[string]interface{}{}
 
 	if len(tweet.Medias) > 0 {
@@ -71,46 +72,12 @@ func (s *Scraper) CreateTweet(tweet NewTweet) (*Tweet, error) {
 	}
 
 	variables := map[string]interface{}{
-		"dark_request":            false,
 		"media":                   post_medias,
 		"semantic_annotation_ids": []string{},
 		"tweet_text":              tweet.Text,
 	}
 
-	features := map[string]interface{}{
-		"communities_web_enable_tweet_community_results_fetch":                    true,
-		"c9s_tweet_anatomy_moderator_badge_enabled":                               true,
-		"tweetypie_unmention_optimization_enabled":                                true,
-		"responsive_web_edit_tweet_api_enabled":                                   true,
-		"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
-		"view_counts_everywhere_api_enabled":                                      true,
-		"longform_notetweets_consumption_enabled":                                 true,
-		"responsive_web_twitter_article_tweet_consumption_enabled":                true,
-		"tweet_awards_web_tipping_enabled":                                        false,
-		"creator_subscriptions_quote_tweet_preview_enabled":                       false,
-		"longform_notetweets_rich_text_read_enabled":                              true,
-		"longform_notetweets_inline_media_enabled":                                true,
-		"articles_preview_enabled":                                                true,
-		"rweb_video_timestamps_enabled":                                           true,
-		"rweb_tipjar_consumption_enabled":                                         true,
-		"responsive_web_graphql_exclude_directive_enabled":                        true,
-		"verified_phone_label_enabled":                                            false,
-		"freedom_of_speech_not_reach_fetch_enabled":                               true,
-		"standardized_nudges_misinfo":                                             true,
-		"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": true,
-		"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
-		"responsive_web_graphql/_timeline_navigation_enabled":                     true,
-		"responsive_web_enhance_cards_enabled":                                    false,
-	}
-
-	body := map[string]interface{}{
-		"features":  features,
-		"variables": variables,
-		"queryId":   "oB-5XsHNAbjvARJEc8CZFw",
-	}
-
-	b, _ := json.Marshal(body)
-	req.Body = io.NopCloser(bytes.NewReader(b))
+	op.encode(req, variables)
 
 	var response newTweet
 	err = s.RequestAPI(req, &response)
@@ -119,31 +86,29 @@ func (s *Scraper) CreateTweet(tweet NewTweet) (*Tweet, error) {
 	}
 
 	if result := response.parse(); result != nil {
-		return result, nil
+		return s.localizeTweet(result), nil
 	}
 
 	return nil, errors.New("tweet wasn't post")
 }
 
 func (s *Scraper) DeleteTweet(tweetId string) error {
-	req, err := s.newRequest("POST", "https://twitter.com/i/api/graphql/VaenaVgh5q5ih7kvyVjgtg/DeleteTweet")
+	return s.DeleteTweetContext(context.Background(), tweetId)
+}
+
+// DeleteTweetContext same as DeleteTweet, but accepts context for cancellation.
+func (s *Scraper) DeleteTweetContext(ctx context.Context, tweetId string) error {
+	op := s.operation("DeleteTweet")
+	req, err := s.newRequest(ctx, op.Method, op.URL())
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("content-type", "application/json")
 	variables := map[string]interface{}{
-		"dark_request": false,
-		"tweet_id":     tweetId,
-	}
-
-	body := map[string]interface{}{
-		"variables": variables,
-		"queryId":   "VaenaVgh5q5ih7kvyVjgtg",
+		"tweet_id": tweetId,
 	}
 
-	b, _ := json.Marshal(body)
-	req.Body = io.NopCloser(bytes.NewReader(b))
+	op.encode(req, variables)
 
 	var response struct {
 		Data struct {
@@ -163,24 +128,22 @@ func (s *Scraper) DeleteTweet(tweetId string) error {
 }
 
 func (s *Scraper) CreateRetweet(tweetId string) (string, error) {
-	req, err := s.newRequest("POST", "https://twitter.com/i/api/graphql/ojPdsZsimiJrUGLR1sjUtA/CreateRetweet")
+	return s.CreateRetweetContext(context.Background(), tweetId)
+}
+
+// CreateRetweetContext same as CreateRetweet, but accepts context for cancellation.
+func (s *Scraper) CreateRetweetContext(ctx context.Context, tweetId string) (string, error) {
+	op := s.operation("CreateRetweet")
+	req, err := s.newRequest(ctx, op.Method, op.URL())
 	if err != nil {
 		return "", err
 	}
 
-	req.Header.Set("content-type", "application/json")
 	variables := map[string]interface{}{
-		"dark_request": false,
-		"tweet_id":     tweetId,
-	}
-
-	body := map[string]interface{}{
-		"variables": variables,
-		"queryId":   "ojPdsZsimiJrUGLR1sjUtA",
+		"tweet_id": tweetId,
 	}
 
-	b, _ := json.Marshal(body)
-	req.Body = io.NopCloser(bytes.NewReader(b))
+	op.encode(req, variables)
 
 	var response struct {
 		Data struct {
@@ -211,23 +174,21 @@ func (s *Scraper) CreateRetweet(tweetId string) (string, error) {
 
 // Retweeted tweets has their own id, but to delete retweet twitter using id of source tweet
 func (s *Scraper) DeleteRetweet(tweetId string) error {
-	req, err := s.newRequest("POST", "https://twitter.com/i/api/graphql/iQtK4dl5hBmXewYZuEOKVw/DeleteRetweet")
+	return s.DeleteRetweetContext(context.Background(), tweetId)
+}
+
+// DeleteRetweetContext same as DeleteRetweet, but accepts context for cancellation.
+func (s *Scraper) DeleteRetweetContext(ctx context.Context, tweetId string) error {
+	op := s.operation("DeleteRetweet")
+	req, err := s.newRequest(ctx, op.Method, op.URL())
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("content-type", "application/json")
 	variables := map[string]interface{}{
-		"dark_request":    false,
 		"source_tweet_id": tweetId,
 	}
-	body := map[string]interface{}{
-		"variables": variables,
-		"queryId":   "iQtK4dl5hBmXewYZuEOKVw",
-	}
-
-	b, _ := json.Marshal(body)
-	req.Body = io.NopCloser(bytes.NewReader(b))
+	op.encode(req, variables)
 
 	var response struct {
 		Data struct {
@@ -253,31 +214,27 @@ func (s *Scraper) DeleteRetweet(tweetId string) error {
 }
 
 func (s *Scraper) LikeTweet(tweetId string) error {
-	req, err := s.newRequest("POST", "https://twitter.com/i/api/graphql/lI07N6Otwv1PhnEgXILM7A/FavoriteTweet")
+	return s.LikeTweetContext(context.Background(), tweetId)
+}
+
+// LikeTweetContext same as LikeTweet, but accepts context for cancellation.
+func (s *Scraper) LikeTweetContext(ctx context.Context, tweetId string) error {
+	op := s.operation("FavoriteTweet")
+	req, err := s.newRequest(ctx, op.Method, op.URL())
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("content-type", "application/json")
 	variables := map[string]interface{}{
 		"tweet_id": tweetId,
 	}
-	body := map[string]interface{}{
-		"variables": variables,
-		"queryId":   "lI07N6Otwv1PhnEgXILM7A",
-	}
-
-	b, _ := json.Marshal(body)
-	req.Body = io.NopCloser(bytes.NewReader(b))
+	op.encode(req, variables)
 
 	var response struct {
 		Data struct {
 			FavoriteTweet string `json:"favorite_tweet"`
 		} `json:"data"`
-		Errors []struct {
-			Message string `json:"message"`
-			Code    int    `json:"code"`
-		} `json:"errors"`
+		Errors []ErrorDetail `json:"errors"`
 	}
 
 	err = s.RequestAPI(req, &response)
@@ -290,6 +247,9 @@ func (s *Scraper) LikeTweet(tweetId string) error {
 	}
 
 	if response.Data.FavoriteTweet != "Done" {
+		if len(response.Errors) > 0 {
+			return &APIError{Errors: response.Errors}
+		}
 		return errors.New("unknown error")
 	}
 
@@ -297,31 +257,27 @@ func (s *Scraper) LikeTweet(tweetId string) error {
 }
 
 func (s *Scraper) UnlikeTweet(tweetId string) error {
-	req, err := s.newRequest("POST", "https://twitter.com/i/api/graphql/ZYKSe-w7KEslx3JhSIk5LA/UnfavoriteTweet")
+	return s.UnlikeTweetContext(context.Background(), tweetId)
+}
+
+// UnlikeTweetContext same as UnlikeTweet, but accepts context for cancellation.
+func (s *Scraper) UnlikeTweetContext(ctx context.Context, tweetId string) error {
+	op := s.operation("UnfavoriteTweet")
+	req, err := s.newRequest(ctx, op.Method, op.URL())
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("content-type", "application/json")
 	variables := map[string]interface{}{
 		"tweet_id": tweetId,
 	}
-	body := map[string]interface{}{
-		"variables": variables,
-		"queryId":   "ZYKSe-w7KEslx3JhSIk5LA",
-	}
-
-	b, _ := json.Marshal(body)
-	req.Body = io.NopCloser(bytes.NewReader(b))
+	op.encode(req, variables)
 
 	var response struct {
 		Data struct {
 			UnfavoriteTweet string `json:"unfavorite_tweet"`
 		} `json:"data"`
-		Errors []struct {
-			Message string `json:"message"`
-			Code    int    `json:"code"`
-		} `json:"errors"`
+		Errors []ErrorDetail `json:"errors"`
 	}
 
 	err = s.RequestAPI(req, &response)
@@ -334,61 +290,40 @@ func (s *Scraper) UnlikeTweet(tweetId string) error {
 	}
 
 	if response.Data.UnfavoriteTweet != "Done" {
+		if len(response.Errors) > 0 {
+			return &APIError{Errors: response.Errors}
+		}
 		return errors.New("unknown error")
 	}
 
 	return nil
 }
 func (s *Scraper) GetTweetRetweeters(tweetId string, maxUsersNbr int, cursor string) ([]*Profile, string, error) {
+	return s.GetTweetRetweetersContext(context.Background(), tweetId, maxUsersNbr, cursor)
+}
+
+// GetTweetRetweetersContext same as GetTweetRetweeters, but accepts context for cancellation.
+func (s *Scraper) GetTweetRetweetersContext(ctx context.Context, tweetId string, maxUsersNbr int, cursor string) ([]*Profile, string, error) {
 	if maxUsersNbr > 200 {
 		maxUsersNbr = 200
 	}
 
-	req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/8019obfgnveiPiJuS2Rtow/Retweeters")
+	op := s.operation("Retweeters")
+	req, err := s.newRequest(ctx, op.Method, op.URL())
 	if err != nil {
 		return nil, "", err
 	}
 
 	variables := map[string]interface{}{
-		"tweetId":                tweetId,
-		"includePromotedContent": false,
-		"count":                  maxUsersNbr,
-	}
-
-	features := map[string]interface{}{
-		"rweb_tipjar_consumption_enabled":                                         true,
-		"responsive_web_graphql_exclude_directive_enabled":                        true,
-		"verified_phone_label_enabled":                                            false,
-		"creator_subscriptions_tweet_preview_api_enabled":                         true,
-		"responsive_web_graphql_timeline_navigation_enabled":                      true,
-		"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
-		"communities_web_enable_tweet_community_results_fetch":                    true,
-		"c9s_tweet_anatomy_moderator_badge_enabled":                               true,
-		"articles_preview_enabled":                                                true,
-		"responsive_web_edit_tweet_api_enabled":                                   true,
-		"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
-		"view_counts_everywhere_api_enabled":                                      true,
-		"longform_notetweets_consumption_enabled":                                 true,
-		"responsive_web_twitter_article_tweet_consumption_enabled":                true,
-		"tweet_awards_web_tipping_enabled":                                        false,
-		"creator_subscriptions_quote_tweet_preview_enabled":                       false,
-		"freedom_of_speech_not_reach_fetch_enabled":                               true,
-		"standardized_nudges_misinfo":                                             true,
-		"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": true,
-		"rweb_video_timestamps_enabled":                                           true,
-		"longform_notetweets_rich_text_read_enabled":                              true,
-		"longform_notetweets_inline_media_enabled":                                true,
-		"responsive_web_enhance_cards_enabled":                                    false,
+		"tweetId": tweetId,
+		"count":   maxUsersNbr,
 	}
 
 	if cursor != "" {
 		variables["cursor"] = cursor
 	}
 
-	query := url.Values{}
-	query.Set("variables", mapToJSONString(variables))
-	query.Set("features", mapToJSONString(features))
-	req.URL.RawQuery = query.Encode()
+	op.encode(req, variables)
 
 	var timeline retweetersTimelineV2
 	err = s.RequestAPI(req, &timeline)
@@ -402,5 +337,5 @@ func (s *Scraper) GetTweetRetweeters(tweetId string, maxUsersNbr int, cursor str
 		nextCursor = ""
 	}
 
-	return users, nextCursor, nil
+	return s.localizeProfiles(users), nextCursor, nil
 }
@@ -116,6 +116,7 @@ func TestGetTweetRetweeters(t *testing.T) {
 	tweetId := "1792634158977568997"
 
 	retweeters, _, err := testScraper.GetTweetRetweeters(tweetId, 20, "")
+	skipIfNotRecorded(t, err)
 	if err != nil {
 		t.Error(err)
 	}
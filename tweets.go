@@ -3,97 +3,79 @@ package twitterscraper
 import (
 	"context"
 	"fmt"
-	"net/url"
 	"strconv"
 )
 
 // GetTweets returns channel with tweets for a given user.
 func (s *Scraper) GetTweets(ctx context.Context, user string, maxTweetsNbr int) <-chan *ScrappedTweetResult {
-	return getTweetTimeline(ctx, user, maxTweetsNbr, s.FetchTweets)
+	return s.getTweetTimeline(ctx, user, maxTweetsNbr, s.FetchTweetsContext)
 }
 
 // GetTweetsAndReplies returns channel with tweets and replies for a given user.
 func (s *Scraper) GetTweetsAndReplies(ctx context.Context, user string, maxTweetsNbr int) <-chan *ScrappedTweetResult {
-	return getTweetTimeline(ctx, user, maxTweetsNbr, s.FetchTweetsAndReplies)
+	return s.getTweetTimeline(ctx, user, maxTweetsNbr, s.FetchTweetsAndRepliesContext)
 }
 
 // FetchTweets gets tweets for a given user, via the Twitter frontend API.
 func (s *Scraper) FetchTweets(user string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
-	userID, err := s.GetUserIDByScreenName(user)
+	return s.FetchTweetsContext(context.Background(), user, maxTweetsNbr, cursor)
+}
+
+// FetchTweetsContext same as FetchTweets, but accepts context for cancellation.
+func (s *Scraper) FetchTweetsContext(ctx context.Context, user string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	userID, err := s.GetUserIDByScreenNameContext(ctx, user)
 	if err != nil {
 		return nil, "", err
 	}
 
-	if s.isOpenAccount {
-		return s.FetchTweetsByUserIDLegacy(userID, maxTweetsNbr, cursor)
+	if s.openAccount() {
+		return s.FetchTweetsByUserIDLegacyContext(ctx, userID, maxTweetsNbr, cursor)
 	}
-	return s.FetchTweetsByUserID(userID, maxTweetsNbr, cursor)
+	return s.FetchTweetsByUserIDContext(ctx, userID, maxTweetsNbr, cursor)
 }
 
 // FetchTweetsAndReplies gets tweets and replies for a given user, via the Twitter frontend API.
 func (s *Scraper) FetchTweetsAndReplies(user string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
-	userID, err := s.GetUserIDByScreenName(user)
+	return s.FetchTweetsAndRepliesContext(context.Background(), user, maxTweetsNbr, cursor)
+}
+
+// FetchTweetsAndRepliesContext same as FetchTweetsAndReplies, but accepts context for cancellation.
+func (s *Scraper) FetchTweetsAndRepliesContext(ctx context.Context, user string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	userID, err := s.GetUserIDByScreenNameContext(ctx, user)
 	if err != nil {
 		return nil, "", err
 	}
 
-	return s.FetchTweetsAndRepliesByUserID(userID, maxTweetsNbr, cursor)
+	return s.FetchTweetsAndRepliesByUserIDContext(ctx, userID, maxTweetsNbr, cursor)
 }
 
 // FetchTweetsAndRepliesByUserID gets tweets and replies for a given userID, via the Twitter frontend GraphQL API.
 func (s *Scraper) FetchTweetsAndRepliesByUserID(userID string, maxReplysNbr int, cursor string) ([]*Tweet, string, error) {
+	return s.FetchTweetsAndRepliesByUserIDContext(context.Background(), userID, maxReplysNbr, cursor)
+}
+
+// FetchTweetsAndRepliesByUserIDContext same as FetchTweetsAndRepliesByUserID, but accepts context for cancellation.
+func (s *Scraper) FetchTweetsAndRepliesByUserIDContext(ctx context.Context, userID string, maxReplysNbr int, cursor string) ([]*Tweet, string, error) {
 	if maxReplysNbr > 200 {
 		maxReplysNbr = 200
 	}
 
-	req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/bt4TKuFz4T7Ckk-VvQVSow/UserTweetsAndReplies")
+	op := s.operation("UserTweetsAndReplies")
+	req, err := s.newRequest(ctx, op.Method, op.URL())
 	if err != nil {
 		return nil, "", err
 	}
 
 	variables := map[string]interface{}{
-		"userId":                                 userID,
-		"count":                                  maxReplysNbr,
-		"includePromotedContent":                 false,
-		"withQuickPromoteEligibilityTweetFields": false,
-		"withVoice":                              true,
-		"withV2Timeline":                         true,
-	}
-
-	features := map[string]interface{}{
-		"rweb_tipjar_consumption_enabled":                                         true,
-		"responsive_web_graphql_exclude_directive_enabled":                        true,
-		"verified_phone_label_enabled":                                            false,
-		"creator_subscriptions_tweet_preview_api_enabled":                         true,
-		"responsive_web_graphql_timeline_navigation_enabled":                      true,
-		"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
-		"communities_web_enable_tweet_community_results_fetch":                    true,
-		"c9s_tweet_anatomy_moderator_badge_enabled":                               true,
-		"articles_preview_enabled":                                                true,
-		"responsive_web_edit_tweet_api_enabled":                                   true,
-		"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
-		"view_counts_everywhere_api_enabled":                                      true,
-		"longform_notetweets_consumption_enabled":                                 true,
-		"responsive_web_twitter_article_tweet_consumption_enabled":                true,
-		"tweet_awards_web_tipping_enabled":                                        false,
-		"creator_subscriptions_quote_tweet_preview_enabled":                       false,
-		"freedom_of_speech_not_reach_fetch_enabled":                               true,
-		"standardized_nudges_misinfo":                                             true,
-		"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": true,
-		"rweb_video_timestamps_enabled":                                           true,
-		"longform_notetweets_rich_text_read_enabled":                              true,
-		"longform_notetweets_inline_media_enabled":                                true,
-		"responsive_web_enhance_cards_enabled":                                    false,
+		"userId": userID,
+		"count":  maxReplysNbr,
 	}
 
 	if cursor != "" {
 		variables["cursor"] = cursor
 	}
 
-	query := url.Values{}
-	query.Set("variables", mapToJSONString(variables))
-	query.Set("features", mapToJSONString(features))
-	req.URL.RawQuery = query.Encode()
+	op.encode(req, variables)
 
 	var timeline timelineV2
 	err = s.RequestAPI(req, &timeline)
@@ -102,60 +84,35 @@ func (s *Scraper) FetchTweetsAndRepliesByUserID(userID string, maxReplysNbr int,
 	}
 
 	tweets, nextCursor := timeline.parseTweets()
-	return tweets, nextCursor, nil
+	return s.localizeTweets(tweets), nextCursor, nil
 }
 
 // FetchTweetsByUserID gets tweets for a given userID, via the Twitter frontend GraphQL API.
 func (s *Scraper) FetchTweetsByUserID(userID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	return s.FetchTweetsByUserIDContext(context.Background(), userID, maxTweetsNbr, cursor)
+}
+
+// FetchTweetsByUserIDContext same as FetchTweetsByUserID, but accepts context for cancellation.
+func (s *Scraper) FetchTweetsByUserIDContext(ctx context.Context, userID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
 	if maxTweetsNbr > 200 {
 		maxTweetsNbr = 200
 	}
 
-	req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/UGi7tjRPr-d_U3bCPIko5Q/UserTweets")
+	op := s.operation("UserTweets")
+	req, err := s.newRequest(ctx, op.Method, op.URL())
 	if err != nil {
 		return nil, "", err
 	}
 
 	variables := map[string]interface{}{
-		"userId":                                 userID,
-		"count":                                  maxTweetsNbr,
-		"includePromotedContent":                 false,
-		"withQuickPromoteEligibilityTweetFields": false,
-		"withVoice":                              true,
-		"withV2Timeline":                         true,
+		"userId": userID,
+		"count":  maxTweetsNbr,
 	}
-	features := map[string]interface{}{
-		"rweb_lists_timeline_redesign_enabled":                              true,
-		"responsive_web_graphql_exclude_directive_enabled":                  true,
-		"verified_phone_label_enabled":                                      false,
-		"creator_subscriptions_tweet_preview_api_enabled":                   true,
-		"responsive_web_graphql_timeline_navigation_enabled":                true,
-		"responsive_web_graphql_skip_user_profile_image_extensions_enabled": false,
-		"tweetypie_unmention_optimization_enabled":                          true,
-		"vibe_api_enabled":                                                        true,
-		"responsive_web_edit_tweet_api_enabled":                                   true,
-		"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
-		"view_counts_everywhere_api_enabled":                                      true,
-		"longform_notetweets_consumption_enabled":                                 true,
-		"tweet_awards_web_tipping_enabled":                                        false,
-		"freedom_of_speech_not_reach_fetch_enabled":                               true,
-		"standardized_nudges_misinfo":                                             true,
-		"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": false,
-		"interactive_text_enabled":                                                true,
-		"responsive_web_text_conversations_enabled":                               false,
-		"longform_notetweets_rich_text_read_enabled":                              true,
-		"longform_notetweets_inline_media_enabled":                                false,
-		"responsive_web_enhance_cards_enabled":                                    false,
-	}
-
 	if cursor != "" {
 		variables["cursor"] = cursor
 	}
 
-	query := url.Values{}
-	query.Set("variables", mapToJSONString(variables))
-	query.Set("features", mapToJSONString(features))
-	req.URL.RawQuery = query.Encode()
+	op.encode(req, variables)
 
 	var timeline timelineV2
 	err = s.RequestAPI(req, &timeline)
@@ -164,16 +121,21 @@ func (s *Scraper) FetchTweetsByUserID(userID string, maxTweetsNbr int, cursor st
 	}
 
 	tweets, nextCursor := timeline.parseTweets()
-	return tweets, nextCursor, nil
+	return s.localizeTweets(tweets), nextCursor, nil
 }
 
 // FetchTweetsByUserIDLegacy gets tweets for a given userID, via the Twitter frontend legacy API.
 func (s *Scraper) FetchTweetsByUserIDLegacy(userID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	return s.FetchTweetsByUserIDLegacyContext(context.Background(), userID, maxTweetsNbr, cursor)
+}
+
+// FetchTweetsByUserIDLegacyContext same as FetchTweetsByUserIDLegacy, but accepts context for cancellation.
+func (s *Scraper) FetchTweetsByUserIDLegacyContext(ctx context.Context, userID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
 	if maxTweetsNbr > 200 {
 		maxTweetsNbr = 200
 	}
 
-	req, err := s.newRequest("GET", "https://api.twitter.com/2/timeline/profile/"+userID+".json")
+	req, err := s.newRequest(ctx, "GET", "https://api.twitter.com/2/timeline/profile/"+userID+".json")
 	if err != nil {
 		return nil, "", err
 	}
@@ -193,13 +155,18 @@ func (s *Scraper) FetchTweetsByUserIDLegacy(userID string, maxTweetsNbr int, cur
 	}
 
 	tweets, nextCursor := timeline.parseTweets()
-	return tweets, nextCursor, nil
+	return s.localizeTweets(tweets), nextCursor, nil
 }
 
 // GetTweet get a single tweet by ID.
 func (s *Scraper) GetTweet(id string) (*Tweet, error) {
-	if s.isOpenAccount {
-		req, err := s.newRequest("GET", "https://api.twitter.com/2/timeline/conversation/"+id+".json")
+	return s.GetTweetContext(context.Background(), id)
+}
+
+// GetTweetContext same as GetTweet, but accepts context for cancellation.
+func (s *Scraper) GetTweetContext(ctx context.Context, id string) (*Tweet, error) {
+	if s.openAccount() {
+		req, err := s.newRequest(ctx, "GET", "https://api.twitter.com/2/timeline/conversation/"+id+".json")
 		if err != nil {
 			return nil, err
 		}
@@ -213,68 +180,30 @@ func (s *Scraper) GetTweet(id string) (*Tweet, error) {
 		tweets, _ := timeline.parseTweets()
 		for _, tweet := range tweets {
 			if tweet.ID == id {
-				return tweet, nil
+				return s.localizeTweet(tweet), nil
 			}
 		}
-	} else if s.isLogged {
-		req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/VWFGPVAGkZMGRKGe3GFFnA/TweetDetail")
+	} else if s.loggedIn() {
+		op := s.operation("TweetDetailSingle")
+		req, err := s.newRequest(ctx, op.Method, op.URL())
 		if err != nil {
 			return nil, err
 		}
 
 		variables := map[string]interface{}{
-			"focalTweetId":                           id,
-			"with_rux_injections":                    false,
-			"includePromotedContent":                 true,
-			"withCommunity":                          true,
-			"withQuickPromoteEligibilityTweetFields": true,
-			"withBirdwatchNotes":                     true,
-			"withVoice":                              true,
-			"withV2Timeline":                         true,
-		}
-
-		features := map[string]interface{}{
-			"rweb_lists_timeline_redesign_enabled":                                    true,
-			"responsive_web_graphql_exclude_directive_enabled":                        true,
-			"verified_phone_label_enabled":                                            false,
-			"creator_subscriptions_tweet_preview_api_enabled":                         true,
-			"responsive_web_graphql_timeline_navigation_enabled":                      true,
-			"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
-			"tweetypie_unmention_optimization_enabled":                                true,
-			"responsive_web_edit_tweet_api_enabled":                                   true,
-			"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
-			"view_counts_everywhere_api_enabled":                                      true,
-			"longform_notetweets_consumption_enabled":                                 true,
-			"tweet_awards_web_tipping_enabled":                                        false,
-			"freedom_of_speech_not_reach_fetch_enabled":                               true,
-			"standardized_nudges_misinfo":                                             true,
-			"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": false,
-			"longform_notetweets_rich_text_read_enabled":                              true,
-			"longform_notetweets_inline_media_enabled":                                true,
-			"responsive_web_enhance_cards_enabled":                                    false,
+			"focalTweetId": id,
 		}
 
-		query := url.Values{}
-		query.Set("variables", mapToJSONString(variables))
-		query.Set("features", mapToJSONString(features))
-		req.URL.RawQuery = query.Encode()
+		op.encode(req, variables)
 
 		var conversation ThreadedConversation
 
 		// Surprisingly, if bearerToken2 is not set, then animated GIFs are not
 		// present in the response for tweets with a GIF + a photo like this one:
 		// https://twitter.com/Twitter/status/1580661436132757506
-		curBearerToken := s.bearerToken
-		if curBearerToken != bearerToken2 {
-			s.setBearerToken(bearerToken2)
-		}
-
+		req = req.WithContext(ContextWithBearerToken(req.Context(), bearerToken2))
 		err = s.RequestAPI(req, &conversation)
 
-		if curBearerToken != bearerToken2 {
-			s.setBearerToken(curBearerToken)
-		}
-
 		if err != nil {
 			return nil, err
 		}
@@ -282,76 +211,37 @@ func (s *Scraper) GetTweet(id string) (*Tweet, error) {
 		tweets, _ := conversation.Parse(id)
 		for _, tweet := range tweets {
 			if tweet.ID == id {
-				return tweet, nil
+				return s.localizeTweet(tweet), nil
 			}
 		}
 	} else {
-		req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/xBtHv5-Xsk268T5ng_OGNg/TweetResultByRestId")
+		op := s.operation("TweetResultByRestId")
+		req, err := s.newRequest(ctx, op.Method, op.URL())
 		if err != nil {
 			return nil, err
 		}
 		variables := map[string]interface{}{
-			"tweetId":                id,
-			"withCommunity":          false,
-			"includePromotedContent": false,
-			"withVoice":              false,
+			"tweetId": id,
 		}
 
-		features := map[string]interface{}{
-			"creator_subscriptions_tweet_preview_api_enabled":                         true,
-			"c9s_tweet_anatomy_moderator_badge_enabled":                               true,
-			"tweetypie_unmention_optimization_enabled":                                true,
-			"responsive_web_edit_tweet_api_enabled":                                   true,
-			"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
-			"view_counts_everywhere_api_enabled":                                      true,
-			"longform_notetweets_consumption_enabled":                                 true,
-			"responsive_web_twitter_article_tweet_consumption_enabled":                true,
-			"tweet_awards_web_tipping_enabled":                                        false,
-			"freedom_of_speech_not_reach_fetch_enabled":                               true,
-			"standardized_nudges_misinfo":                                             true,
-			"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": true,
-			"rweb_video_timestamps_enabled":                                           true,
-			"longform_notetweets_rich_text_read_enabled":                              true,
-			"longform_notetweets_inline_media_enabled":                                true,
-			"responsive_web_graphql_exclude_directive_enabled":                        true,
-			"verified_phone_label_enabled":                                            false,
-			"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
-			"responsive_web_graphql_timeline_navigation_enabled":                      true,
-			"responsive_web_enhance_cards_enabled":                                    false,
-		}
-
-		fieldToggles := map[string]interface{}{"withArticleRichContentState": true}
-
-		query := url.Values{}
-		query.Set("variables", mapToJSONString(variables))
-		query.Set("features", mapToJSONString(features))
-		query.Set("fieldToggles", mapToJSONString(fieldToggles))
-		req.URL.RawQuery = query.Encode()
+		op.encode(req, variables)
 
 		var result TweetResult
 
 		// Surprisingly, if bearerToken2 is not set, then animated GIFs are not
 		// present in the response for tweets with a GIF + a photo like this one:
 		// https://twitter.com/Twitter/status/1580661436132757506
-		curBearerToken := s.bearerToken
-		if curBearerToken != bearerToken2 {
-			s.setBearerToken(bearerToken2)
-		}
-
+		req = req.WithContext(ContextWithBearerToken(req.Context(), bearerToken2))
 		err = s.RequestAPI(req, &result)
 
-		if curBearerToken != bearerToken2 {
-			s.setBearerToken(curBearerToken)
-		}
-
 		if err != nil {
 			return nil, err
 		}
 
 		tweet := result.Parse()
-		return tweet, nil
+		return s.localizeTweet(tweet), nil
 	}
-	return nil, fmt.Errorf("tweet with ID %s not found", id)
+	return nil, fmt.Errorf("tweet with ID %s %w", id, ErrNotFound)
 }
 
 type homeEntry struct {
@@ -408,68 +298,39 @@ func (timeline *homeTimeline) parseTweets() ([]*Tweet, string) {
 
 // GetHomeTweets returns channel with tweets from home timeline
 func (s *Scraper) GetHomeTweets(ctx context.Context, maxTweetsNbr int) <-chan *ScrappedTweetResult {
-	return getTweetTimeline(ctx, "", maxTweetsNbr, s.fetchHomeTweets)
+	return s.getTweetTimeline(ctx, "", maxTweetsNbr, s.fetchHomeTweets)
 }
 
 func (s *Scraper) FetchHomeTweets(maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
-	return s.fetchHomeTweets("", maxTweetsNbr, cursor)
+	return s.FetchHomeTweetsContext(context.Background(), maxTweetsNbr, cursor)
+}
+
+// FetchHomeTweetsContext same as FetchHomeTweets, but accepts context for cancellation.
+func (s *Scraper) FetchHomeTweetsContext(ctx context.Context, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	return s.fetchHomeTweets(ctx, "", maxTweetsNbr, cursor)
 }
 
 // FetchHomeTweets gets tweets from home timline, via the Twitter frontend API.
-func (s *Scraper) fetchHomeTweets(_ string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+func (s *Scraper) fetchHomeTweets(ctx context.Context, _ string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
 	if maxTweetsNbr > 200 {
 		maxTweetsNbr = 200
 	}
 
-	req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/9EwYy8pLBOSFlEoSP2STiQ/HomeLatestTimeline")
+	op := s.operation("HomeLatestTimeline")
+	req, err := s.newRequest(ctx, op.Method, op.URL())
 	if err != nil {
 		return nil, "", err
 	}
 
 	variables := map[string]interface{}{
-		"count":                                  maxTweetsNbr,
-		"includePromotedContent":                 true,
-		"withQuickPromoteEligibilityTweetFields": true,
-		"requestContext":                         "launch",
+		"count": maxTweetsNbr,
 	}
 
 	if cursor != "" {
 		variables["cursor"] = cursor
 	}
 
-	features := map[string]interface{}{
-		"rweb_tipjar_consumption_enabled":                                         true,
-		"responsive_web_graphql_exclude_directive_enabled":                        true,
-		"verified_phone_label_enabled":                                            false,
-		"creator_subscriptions_tweet_preview_api_enabled":                         true,
-		"responsive_web_graphql_timeline_navigation_enabled":                      true,
-		"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
-		"communities_web_enable_tweet_community_results_fetch":                    true,
-		"c9s_tweet_anatomy_moderator_badge_enabled":                               true,
-		"articles_preview_enabled":                                                true,
-		"tweetypie_unmention_optimization_enabled":                                true,
-		"responsive_web_edit_tweet_api_enabled":                                   true,
-		"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
-		"view_counts_everywhere_api_enabled":                                      true,
-		"longform_notetweets_consumption_enabled":                                 true,
-		"responsive_web_twitter_article_tweet_consumption_enabled":                true,
-		"tweet_awards_web_tipping_enabled":                                        false,
-		"creator_subscriptions_quote_tweet_preview_enabled":                       false,
-		"freedom_of_speech_not_reach_fetch_enabled":                               true,
-		"standardized_nudges_misinfo":                                             true,
-		"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": true,
-		"rweb_video_timestamps_enabled":                                           true,
-		"longform_notetweets_rich_text_read_enabled":                              true,
-		"longform_notetweets_inline_media_enabled":                                true,
-		"responsive_web_enhance_cards_enabled":                                    false,
-	}
-
-	req.Header.Set("content-type", "application/json")
-
-	query := url.Values{}
-	query.Set("variables", mapToJSONString(variables))
-	query.Set("features", mapToJSONString(features))
-	req.URL.RawQuery = query.Encode()
+	op.encode(req, variables)
 
 	var timeline homeTimeline
 	err = s.RequestAPI(req, &timeline)
@@ -478,74 +339,44 @@ func (s *Scraper) fetchHomeTweets(_ string, maxTweetsNbr int, cursor string) ([]
 	}
 
 	tweets, nextCursor := timeline.parseTweets()
-	return tweets, nextCursor, nil
+	return s.localizeTweets(tweets), nextCursor, nil
 }
 
 // GetForYouTweets returns channel with tweets from for you timeline
 func (s *Scraper) GetForYouTweets(ctx context.Context, maxTweetsNbr int) <-chan *ScrappedTweetResult {
-	return getTweetTimeline(ctx, "", maxTweetsNbr, s.fetchForYouTweets)
+	return s.getTweetTimeline(ctx, "", maxTweetsNbr, s.fetchForYouTweets)
 }
 
 func (s *Scraper) FetchForYouTweets(maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
-	return s.fetchForYouTweets("", maxTweetsNbr, cursor)
+	return s.FetchForYouTweetsContext(context.Background(), maxTweetsNbr, cursor)
+}
+
+// FetchForYouTweetsContext same as FetchForYouTweets, but accepts context for cancellation.
+func (s *Scraper) FetchForYouTweetsContext(ctx context.Context, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	return s.fetchForYouTweets(ctx, "", maxTweetsNbr, cursor)
 }
 
 // FetchForYouTweets gets tweets from for you timline, via the Twitter frontend API.
-func (s *Scraper) fetchForYouTweets(_ string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+func (s *Scraper) fetchForYouTweets(ctx context.Context, _ string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
 	if maxTweetsNbr > 200 {
 		maxTweetsNbr = 200
 	}
 
-	req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/1u0Wlkw6Ru1NwBUD-pDiww/HomeTimeline")
+	op := s.operation("HomeTimeline")
+	req, err := s.newRequest(ctx, op.Method, op.URL())
 	if err != nil {
 		return nil, "", err
 	}
 
 	variables := map[string]interface{}{
-		"count":                  maxTweetsNbr,
-		"includePromotedContent": true,
-		"latestControlAvailable": true,
-		"requestContext":         "launch",
-		"withCommunity":          true,
+		"count": maxTweetsNbr,
 	}
 
 	if cursor != "" {
 		variables["cursor"] = cursor
 	}
 
-	features := map[string]interface{}{
-		"rweb_tipjar_consumption_enabled":                                         true,
-		"responsive_web_graphql_exclude_directive_enabled":                        true,
-		"verified_phone_label_enabled":                                            false,
-		"creator_subscriptions_tweet_preview_api_enabled":                         true,
-		"responsive_web_graphql_timeline_navigation_enabled":                      true,
-		"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
-		"communities_web_enable_tweet_community_results_fetch":                    true,
-		"c9s_tweet_anatomy_moderator_badge_enabled":                               true,
-		"articles_preview_enabled":                                                true,
-		"tweetypie_unmention_optimization_enabled":                                true,
-		"responsive_web_edit_tweet_api_enabled":                                   true,
-		"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
-		"view_counts_everywhere_api_enabled":                                      true,
-		"longform_notetweets_consumption_enabled":                                 true,
-		"responsive_web_twitter_article_tweet_consumption_enabled":                true,
-		"tweet_awards_web_tipping_enabled":                                        false,
-		"creator_subscriptions_quote_tweet_preview_enabled":                       false,
-		"freedom_of_speech_not_reach_fetch_enabled":                               true,
-		"standardized_nudges_misinfo":                                             true,
-		"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": true,
-		"rweb_video_timestamps_enabled":                                           true,
-		"longform_notetweets_rich_text_read_enabled":                              true,
-		"longform_notetweets_inline_media_enabled":                                true,
-		"responsive_web_enhance_cards_enabled":                                    false,
-	}
-
-	req.Header.Set("content-type", "application/json")
-
-	query := url.Values{}
-	query.Set("variables", mapToJSONString(variables))
-	query.Set("features", mapToJSONString(features))
-	req.URL.RawQuery = query.Encode()
+	op.encode(req, variables)
 
 	var timeline homeTimeline
 	err = s.RequestAPI(req, &timeline)
@@ -554,5 +385,5 @@ func (s *Scraper) fetchForYouTweets(_ string, maxTweetsNbr int, cursor string) (
 	}
 
 	tweets, nextCursor := timeline.parseTweets()
-	return tweets, nextCursor, nil
+	return s.localizeTweets(tweets), nextCursor, nil
 }
@@ -25,6 +25,7 @@ func TestGetSingleTweet(t *testing.T) {
 		t.Skip("Skipping test due to environment variable")
 	}
 	tweet, err := testScraper.GetTweet("1917367341022609778")
+	skipIfNotRecorded(t, err)
 	if err != nil {
 		t.Error(err)
 	} else {
@@ -58,6 +59,7 @@ func TestGetTweets(t *testing.T) {
 	maxTweetsNbr := 100
 	dupcheck := make(map[string]bool)
 	for tweet := range testScraper.GetTweets(context.Background(), "x", maxTweetsNbr) {
+		skipIfNotRecorded(t, tweet.Error)
 		if tweet.Error != nil {
 			t.Error(tweet.Error)
 		} else {
@@ -110,6 +112,7 @@ func TestGetTweets(t *testing.T) {
 func assertGetTweet(t *testing.T, expectedTweet *twitterscraper.Tweet) {
 	// to get tweet as struct fmt.Printf("%#v", actualTweet)
 	actualTweet, err := testScraper.GetTweet(expectedTweet.ID)
+	skipIfNotRecorded(t, err)
 	if err != nil {
 		t.Error(err)
 	} else if diff := cmp.Diff(expectedTweet, actualTweet, cmpOptions...); diff != "" {
@@ -218,6 +221,7 @@ func TestTweetMentions(t *testing.T) {
 		Name:     "David McRaney",
 	}}
 	tweet, err := testScraper.GetTweet("1554522888904101890")
+	skipIfNotRecorded(t, err)
 	if err != nil {
 		t.Error(err)
 	} else {
@@ -248,6 +252,7 @@ func TestQuotedAndReply(t *testing.T) {
 		Username:  "VsauceTwo",
 	}
 	tweet, err := testScraper.GetTweet("1237110897597976576")
+	skipIfNotRecorded(t, err)
 	if err != nil {
 		t.Error(err)
 	} else {
@@ -259,6 +264,7 @@ func TestQuotedAndReply(t *testing.T) {
 		}
 	}
 	tweet, err = testScraper.GetTweet("1237111868445134850")
+	skipIfNotRecorded(t, err)
 	if err != nil {
 		t.Error(err)
 	} else {
@@ -286,6 +292,7 @@ func TestRetweet(t *testing.T) {
 		Username:       "premium",
 	}
 	tweet, err := testScraper.GetTweet("1758837226379596068")
+	skipIfNotRecorded(t, err)
 	if err != nil {
 		t.Error(err)
 	} else {
@@ -314,6 +321,7 @@ func TestTweetViews(t *testing.T) {
 		Views:        3189278,
 	}
 	tweet, err := testScraper.GetTweet("1606055187348688896")
+	skipIfNotRecorded(t, err)
 	if err != nil {
 		t.Error(err)
 	} else {
@@ -328,6 +336,7 @@ func TestTweetThread(t *testing.T) {
 		t.Skip("Skipping test due to environment variable")
 	}
 	tweet, err := testScraper.GetTweet("1665602315745673217")
+	skipIfNotRecorded(t, err)
 	if err != nil {
 		t.Fatal(err)
 	} else {
@@ -345,6 +354,7 @@ func TestFetchHomeTweets(t *testing.T) {
 		t.Skip("Skipping test due to environment variable")
 	}
 	tweets, _, err := testScraper.FetchHomeTweets(20, "")
+	skipIfNotRecorded(t, err)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -364,6 +374,7 @@ func TestGetHomeTweets(t *testing.T) {
 
 	for tweet := range testScraper.GetHomeTweets(context.Background(), maxTweetsNbr) {
 		if tweet.Error != nil {
+			skipIfNotRecorded(t, tweet.Error)
 			t.Error(tweet.Error)
 		} else {
 			count++
@@ -418,6 +429,7 @@ func TestFetchForYouTweets(t *testing.T) {
 		t.Skip("Skipping test due to environment variable")
 	}
 	tweets, _, err := testScraper.FetchForYouTweets(20, "")
+	skipIfNotRecorded(t, err)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -437,6 +449,7 @@ func TestGetForYouTweets(t *testing.T) {
 
 	for tweet := range testScraper.GetForYouTweets(context.Background(), maxTweetsNbr) {
 		if tweet.Error != nil {
+			skipIfNotRecorded(t, tweet.Error)
 			t.Error(tweet.Error)
 		} else {
 			count++
@@ -492,6 +505,7 @@ func TestGetTweetsAndReplies(t *testing.T) {
 	}
 
 	tweets, _, err := testScraper.FetchTweetsAndRepliesByUserID("17874544", 20, "")
+	skipIfNotRecorded(t, err)
 	if err != nil {
 		t.Error(err)
 	}
@@ -1,6 +1,9 @@
 package twitterscraper
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 type (
 	// Mention type.
@@ -91,6 +94,79 @@ type (
 		Views  int     `bson:"views,omitempty" json:"views,omitempty"`
 
 		SensitiveContent bool `bson:"sensitive_content,omitempty" json:"sensitive_content,omitempty"`
+
+		// EditTweetIDs of every version of the tweet in order, the first one is the initial tweet
+		EditTweetIDs   []string  `bson:"edit_tweet_ids,omitempty" json:"edit_tweet_ids,omitempty"`
+		EditableUntil  time.Time `bson:"editable_until,omitempty" json:"editable_until,omitempty"`
+		EditsRemaining int       `bson:"edits_remaining,omitempty" json:"edits_remaining,omitempty"`
+		InitialTweetID string    `bson:"initial_tweet_id,omitempty" json:"initial_tweet_id,omitempty"`
+		IsEditEligible bool      `bson:"is_edit_eligible,omitempty" json:"is_edit_eligible,omitempty"`
+		// IsEdit reports whether the tweet is an edited version of the initial tweet
+		IsEdit bool `bson:"is_edit,omitempty" json:"is_edit,omitempty"`
+
+		CommunityNote *CommunityNote `bson:"community_note,omitempty" json:"community_note,omitempty"`
+
+		Poll *Poll `bson:"poll,omitempty" json:"poll,omitempty"`
+		Card *Card `bson:"card,omitempty" json:"card,omitempty"`
+	}
+
+	// Poll attached to the tweet.
+	Poll struct {
+		ID              string       `bson:"id,omitempty" json:"id,omitempty"`
+		Choices         []PollChoice `bson:"choices,omitempty" json:"choices,omitempty"`
+		DurationMinutes int          `bson:"duration_minutes,omitempty" json:"duration_minutes,omitempty"`
+		EndDateTime     time.Time    `bson:"end_datetime,omitempty" json:"end_datetime,omitempty"`
+		// CountsAreFinal reports whether the poll is closed
+		CountsAreFinal bool `bson:"counts_are_final,omitempty" json:"counts_are_final,omitempty"`
+	}
+
+	// PollChoice with its vote count.
+	PollChoice struct {
+		Label string `bson:"label,omitempty" json:"label,omitempty"`
+		Count int    `bson:"count,omitempty" json:"count,omitempty"`
+	}
+
+	// Card of a link preview, player or poll attached to the tweet.
+	Card struct {
+		// Name of the card type, like `summary_large_image`, `player` or `poll2choice_text_only`
+		Name        string `bson:"name,omitempty" json:"name,omitempty"`
+		URL         string `bson:"url,omitempty" json:"url,omitempty"`
+		Title       string `bson:"title,omitempty" json:"title,omitempty"`
+		Description string `bson:"description,omitempty" json:"description,omitempty"`
+		Domain      string `bson:"domain,omitempty" json:"domain,omitempty"`
+		// Values of string and boolean bindings by key, like `player_url`
+		Values map[string]string `bson:"values,omitempty" json:"values,omitempty"`
+		// Images of image bindings by key, like `thumbnail_image_large`
+		Images map[string]CardImage `bson:"images,omitempty" json:"images,omitempty"`
+	}
+
+	// CardImage is an image binding of a Card.
+	CardImage struct {
+		URL    string `bson:"url,omitempty" json:"url,omitempty"`
+		Width  int    `bson:"width,omitempty" json:"width,omitempty"`
+		Height int    `bson:"height,omitempty" json:"height,omitempty"`
+		Alt    string `bson:"alt,omitempty" json:"alt,omitempty"`
+	}
+
+	// CommunityNote added to the tweet by readers, formerly Birdwatch.
+	CommunityNote struct {
+		ID   string `bson:"id,omitempty" json:"id,omitempty"`
+		Text string `bson:"text,omitempty" json:"text,omitempty"`
+		// Entities of links in Text
+		Entities []NoteEntity `bson:"entities,omitempty" json:"entities,omitempty"`
+		// RatingStatus is NoteCurrentlyRatedHelpful or NoteNeedsMoreRatings, empty if unknown
+		RatingStatus string `bson:"rating_status,omitempty" json:"rating_status,omitempty"`
+		Title        string `bson:"title,omitempty" json:"title,omitempty"`
+		URL          string `bson:"url,omitempty" json:"url,omitempty"`
+	}
+
+	// NoteEntity is a link in the text of a CommunityNote.
+	NoteEntity struct {
+		FromIndex int    `bson:"from_index,omitempty" json:"from_index,omitempty"`
+		ToIndex   int    `bson:"to_index,omitempty" json:"to_index,omitempty"`
+		URL       string `bson:"url,omitempty" json:"url,omitempty"`
+		// Type of the link, like `ExternalUrl`
+		Type string `bson:"type,omitempty" json:"type,omitempty"`
 	}
 
 	// ProfileResult of scrapping.
@@ -292,6 +368,20 @@ type (
 			State string `bson:"state,omitempty" json:"state,omitempty"`
 			Count string `bson:"count,omitempty" json:"count,omitempty"`
 		} `bson:"ext_views,omitempty" json:"ext_views,omitempty"`
+		EditControl *editControl `bson:"edit_control,omitempty" json:"edit_control,omitempty"`
+		Card        *legacyCard  `bson:"card,omitempty" json:"card,omitempty"`
+		Ext         struct {
+			EditControl struct {
+				R struct {
+					Ok *legacyEditControl `bson:"ok,omitempty" json:"ok,omitempty"`
+				} `bson:"r,omitempty" json:"r,omitempty"`
+			} `bson:"editControl,omitempty" json:"editControl,omitempty"`
+			BirdwatchPivot struct {
+				R struct {
+					Ok *birdwatchPivot `bson:"ok,omitempty" json:"ok,omitempty"`
+				} `bson:"r,omitempty" json:"r,omitempty"`
+			} `bson:"birdwatchPivot,omitempty" json:"birdwatchPivot,omitempty"`
+		} `bson:"ext,omitempty" json:"ext,omitempty"`
 	}
 
 	Place struct {
@@ -307,8 +397,8 @@ type (
 		} `bson:"bounding_box,omitempty" json:"bounding_box,omitempty"`
 	}
 
-	fetchProfileFunc func(query string, maxProfilesNbr int, cursor string) ([]*Profile, string, error)
-	fetchTweetFunc   func(query string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error)
+	fetchProfileFunc func(ctx context.Context, query string, maxProfilesNbr int, cursor string) ([]*Profile, string, error)
+	fetchTweetFunc   func(ctx context.Context, query string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error)
 
 	legacyExtendedProfile struct {
 		Birthdate struct {
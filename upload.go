@@ -2,6 +2,7 @@ package twitterscraper
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"log"
@@ -41,24 +42,29 @@ type uploadStatusResponse struct {
 
 // Uploads photo, video or gif for further posting or scheduling. Expires in 24 hours if not used.
 func (s *Scraper) UploadMedia(filePath string) (*Media, error) {
+	return s.UploadMediaContext(context.Background(), filePath)
+}
+
+// UploadMediaContext same as UploadMedia, but accepts context for cancellation.
+func (s *Scraper) UploadMediaContext(ctx context.Context, filePath string) (*Media, error) {
 	fileContent, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, err
 	}
 
-	media, err := s.uploadInit(filePath, fileContent)
+	media, err := s.uploadInit(ctx, filePath, fileContent)
 	if err != nil {
 		return nil, err
 	}
 
-	err = s.uploadAppend(media, fileContent)
+	err = s.uploadAppend(ctx, media, fileContent)
 	if err != nil {
 		return nil, err
 	}
 
 	var status *ProcessingInfo
 
-	status, err = s.uploadFinalize(media)
+	status, err = s.uploadFinalize(ctx, media)
 	if err != nil {
 		return nil, err
 	}
@@ -68,8 +74,10 @@ func (s *Scraper) UploadMedia(filePath string) (*Media, error) {
 	}
 
 	for status.State != "succeeded" {
-		time.Sleep(2 * time.Second)
-		status, err = s.uploadStatus(media)
+		if err := sleepContext(ctx, 2*time.Second); err != nil {
+			return nil, err
+		}
+		status, err = s.uploadStatus(ctx, media)
 		if err != nil {
 			return nil, err
 		}
@@ -78,7 +86,7 @@ func (s *Scraper) UploadMedia(filePath string) (*Media, error) {
 	return media, nil
 }
 
-func (s *Scraper) uploadInit(filePath string, fileContent []byte) (*Media, error) {
+func (s *Scraper) uploadInit(ctx context.Context, filePath string, fileContent []byte) (*Media, error) {
 	var (
 		videoDuration float64
 		fileType      string
@@ -104,7 +112,7 @@ func (s *Scraper) uploadInit(filePath string, fileContent []byte) (*Media, error
 		return nil, fmt.Errorf("file type %s unsupported by twitter, make sure you uploading photo, video or gif", fileType)
 	}
 
-	req, err := s.newRequest("POST", "https://upload.twitter.com/i/media/upload.json")
+	req, err := s.newRequest(ctx, "POST", "https://upload.twitter.com/i/media/upload.json")
 	if err != nil {
 		return nil, err
 	}
@@ -118,8 +126,8 @@ func (s *Scraper) uploadInit(filePath string, fileContent []byte) (*Media, error
 		query.Set("video_duration_ms", strconv.FormatFloat(videoDuration*1000, 'f', -1, 64))
 	}
 	req.URL.RawQuery = query.Encode()
-	req.Header.Set("Origin", "https://twitter.com")
-	req.Header.Set("Referer", "https://twitter.com/")
+	req.Header.Set("Origin", s.webOrigin())
+	req.Header.Set("Referer", s.webOrigin()+"/")
 
 	var uploadInit uploadInitResponse
 
@@ -137,7 +145,7 @@ func (s *Scraper) uploadInit(filePath string, fileContent []byte) (*Media, error
 	}, nil
 }
 
-func (s *Scraper) uploadAppend(media *Media, fileContent []byte) error {
+func (s *Scraper) uploadAppend(ctx context.Context, media *Media, fileContent []byte) error {
 	for i := 0; i <= media.Parts; i++ {
 		var partData []byte
 
@@ -158,7 +166,8 @@ func (s *Scraper) uploadAppend(media *Media, fileContent []byte) error {
 		}
 		w.Close()
 
-		req, err := s.newRequest("POST", "https://upload.twitter.com/i/media/upload.json")
+		// APPEND of the same segment index replaces it, safe to retry
+		req, err := s.newRequest(contextIdempotent(ctx), "POST", "https://upload.twitter.com/i/media/upload.json")
 		if err != nil {
 			return err
 		}
@@ -169,8 +178,8 @@ func (s *Scraper) uploadAppend(media *Media, fileContent []byte) error {
 		query.Set("segment_index", strconv.Itoa(i))
 		req.URL.RawQuery = query.Encode()
 		req.Header.Set("Content-Type", w.FormDataContentType())
-		req.Header.Set("Origin", "https://twitter.com")
-		req.Header.Set("Referer", "https://twitter.com/")
+		req.Header.Set("Origin", s.webOrigin())
+		req.Header.Set("Referer", s.webOrigin()+"/")
 		req.Body = io.NopCloser(&buf)
 
 		err = s.RequestAPI(req, nil)
@@ -182,8 +191,8 @@ func (s *Scraper) uploadAppend(media *Media, fileContent []byte) error {
 	return nil
 }
 
-func (s *Scraper) uploadFinalize(media *Media) (*ProcessingInfo, error) {
-	req, err := s.newRequest("POST", "https://upload.twitter.com/i/media/upload.json")
+func (s *Scraper) uploadFinalize(ctx context.Context, media *Media) (*ProcessingInfo, error) {
+	req, err := s.newRequest(contextIdempotent(ctx), "POST", "https://upload.twitter.com/i/media/upload.json")
 	if err != nil {
 		return nil, err
 	}
@@ -193,8 +202,8 @@ func (s *Scraper) uploadFinalize(media *Media) (*ProcessingInfo, error) {
 	query.Set("media_id", strconv.Itoa(media.ID))
 	query.Set("allow_async", "true")
 	req.URL.RawQuery = query.Encode()
-	req.Header.Set("Origin", "https://twitter.com")
-	req.Header.Set("Referer", "https://twitter.com/")
+	req.Header.Set("Origin", s.webOrigin())
+	req.Header.Set("Referer", s.webOrigin()+"/")
 
 	var response uploadStatusResponse
 
@@ -206,8 +215,8 @@ func (s *Scraper) uploadFinalize(media *Media) (*ProcessingInfo, error) {
 	return &response.ProcessingInfo, nil
 }
 
-func (s *Scraper) uploadStatus(media *Media) (*ProcessingInfo, error) {
-	req, err := s.newRequest("GET", "https://upload.twitter.com/i/media/upload.json")
+func (s *Scraper) uploadStatus(ctx context.Context, media *Media) (*ProcessingInfo, error) {
+	req, err := s.newRequest(ctx, "GET", "https://upload.twitter.com/i/media/upload.json")
 	if err != nil {
 		return nil, err
 	}
@@ -216,8 +225,8 @@ func (s *Scraper) uploadStatus(media *Media) (*ProcessingInfo, error) {
 	query.Set("command", "STATUS")
 	query.Set("media_id", strconv.Itoa(media.ID))
 	req.URL.RawQuery = query.Encode()
-	req.Header.Set("Origin", "https://twitter.com")
-	req.Header.Set("Referer", "https://twitter.com/")
+	req.Header.Set("Origin", s.webOrigin())
+	req.Header.Set("Referer", s.webOrigin()+"/")
 
 	var response uploadStatusResponse
 
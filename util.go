@@ -22,8 +22,8 @@ var (
 	twURL        = urlParse("https://twitter.com")
 )
 
-func (s *Scraper) newRequest(method string, url string) (*http.Request, error) {
-	req, err := http.NewRequest(method, url, nil)
+func (s *Scraper) newRequest(ctx context.Context, method string, url string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -58,15 +58,27 @@ func (s *Scraper) newRequest(method string, url string) (*http.Request, error) {
 	q.Add("include_ext_trusted_friends_metadata", "true")
 	q.Add("send_error_codes", "true")
 	q.Add("simple_quoted_tweet", "true")
-	q.Add("include_tweet_replies", strconv.FormatBool(s.includeReplies))
+	q.Add("include_tweet_replies", strconv.FormatBool(s.includeRepliesFor(ctx)))
 	q.Add("ext", "mediaStats,highlightedLabel,hasNftAvatar,voiceInfo,birdwatchPivot,enrichments,superFollowMetadata,unmentionInfo,editControl,collab_control,vibe")
 	req.URL.RawQuery = q.Encode()
 
-	req.Header.Set("X-Client-Transaction-Id", generateTxnID())
+	req.Header.Set("X-Client-Transaction-Id", s.transactionID(method, req.URL.Path))
 
 	return req, nil
 }
 
+// sleepContext pauses for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // generateTxnID returns a URL-safe, padding-free 16-byte random string.
 func generateTxnID() string {
 	buf := make([]byte, 16)
@@ -78,8 +90,9 @@ func generateTxnID() string {
 	return base64.RawURLEncoding.EncodeToString(buf)
 }
 
-func getUserTimeline(ctx context.Context, query string, maxProfilesNbr int, fetchFunc fetchProfileFunc) <-chan *ProfileResult {
+func (s *Scraper) getUserTimeline(ctx context.Context, query string, maxProfilesNbr int, fetchFunc fetchProfileFunc) <-chan *ProfileResult {
 	channel := make(chan *ProfileResult)
+	metrics := s.getMetrics()
 	go func(query string) {
 		defer close(channel)
 		var nextCursor string
@@ -92,7 +105,7 @@ func getUserTimeline(ctx context.Context, query string, maxProfilesNbr int, fetc
 			default:
 			}
 
-			profiles, next, err := fetchFunc(query, maxProfilesNbr, nextCursor)
+			profiles, next, err := fetchFunc(ctx, query, maxProfilesNbr, nextCursor)
 			if err != nil {
 				channel <- &ProfileResult{Error: err}
 				return
@@ -113,6 +126,7 @@ func getUserTimeline(ctx context.Context, query string, maxProfilesNbr int, fetc
 				if profilesNbr < maxProfilesNbr {
 					nextCursor = next
 					channel <- &ProfileResult{Profile: *profile}
+					metrics.AddProfiles(1)
 				} else {
 					break
 				}
@@ -123,8 +137,9 @@ func getUserTimeline(ctx context.Context, query string, maxProfilesNbr int, fetc
 	return channel
 }
 
-func getTweetTimeline(ctx context.Context, query string, maxTweetsNbr int, fetchFunc fetchTweetFunc) <-chan *ScrappedTweetResult {
+func (s *Scraper) getTweetTimeline(ctx context.Context, query string, maxTweetsNbr int, fetchFunc fetchTweetFunc) <-chan *ScrappedTweetResult {
 	channel := make(chan *ScrappedTweetResult)
+	metrics := s.getMetrics()
 	go func(query string) {
 		defer close(channel)
 		var nextCursor string
@@ -137,7 +152,7 @@ func getTweetTimeline(ctx context.Context, query string, maxTweetsNbr int, fetch
 			default:
 			}
 
-			tweets, next, err := fetchFunc(query, maxTweetsNbr, nextCursor)
+			tweets, next, err := fetchFunc(ctx, query, maxTweetsNbr, nextCursor)
 			if err != nil {
 				channel <- &ScrappedTweetResult{Error: err}
 				return
@@ -158,6 +173,7 @@ func getTweetTimeline(ctx context.Context, query string, maxTweetsNbr int, fetch
 				if tweetsNbr < maxTweetsNbr {
 					nextCursor = next
 					channel <- &ScrappedTweetResult{Tweet: *tweet}
+					metrics.AddTweets(1)
 				} else {
 					break
 				}
@@ -201,6 +217,9 @@ func parseLegacyTweet(user *UserV2, tweet *legacyTweet) *Tweet {
 	if tweet.Place.ID != "" {
 		tw.Place = &tweet.Place
 	}
+	tweet.editControl().apply(tw)
+	tw.CommunityNote = tweet.Ext.BirdwatchPivot.R.Ok.parse()
+	tw.Card, tw.Poll = tweet.Card.parse()
 
 	if tweet.QuotedStatusIDStr != "" {
 		tw.IsQuoted = true